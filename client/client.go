@@ -7,9 +7,8 @@ import (
 	"net/http"
 
 	"github.com/deepmap/oapi-codegen/pkg/securityprovider"
-	"github.com/hashicorp/go-cleanhttp"
-	"github.com/hashicorp/go-retryablehttp"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func New(ctx context.Context, apiKey, apiEndpoint, version string, opts ...ClientOption) (*ClientWithResponses, error) {
@@ -18,16 +17,21 @@ func New(ctx context.Context, apiKey, apiEndpoint, version string, opts ...Clien
 		return nil, bearerTokenProviderErr
 	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 3
+	// Each attempt, including retries, is observed so we can measure them.
+	retryClient := newRetryClient(ctx, func(next http.RoundTripper) http.RoundTripper {
+		return Wrap(next, observeAttempt)
+	})
 
 	base := retryClient.StandardClient()
 
 	// The generated client won't turn validation errors into actual errors, so we do this
-	// inside of a generic middleware.
-	base.Transport = Wrap(cleanhttp.DefaultTransport(), func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
-		resp, err := next.RoundTrip(req)
+	// inside of a generic middleware. This is outside the retries, so only the response to
+	// the last attempt becomes an error.
+	base.Transport = Wrap(base.Transport, func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		resp, err := next.RoundTrip(withAttempts(req))
 		if err == nil && resp.StatusCode > 299 {
+			defer resp.Body.Close()
+
 			data, err := io.ReadAll(resp.Body)
 			if err != nil {
 				return nil, fmt.Errorf("status %d: no response body", resp.StatusCode)
@@ -84,3 +88,17 @@ func Wrap(next http.RoundTripper, apply func(req *http.Request, next http.RoundT
 		return apply(req, next)
 	})
 }
+
+// WithTracing creates an OpenTelemetry span for every request, as a child of any span in
+// the request's context, and propagates the trace in the request headers.
+func WithTracing() ClientOption {
+	return func(c *Client) error {
+		httpClient, ok := c.Client.(*http.Client)
+		if !ok {
+			return errors.New("tracing requires an *http.Client")
+		}
+
+		httpClient.Transport = otelhttp.NewTransport(httpClient.Transport)
+		return nil
+	}
+}
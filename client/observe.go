@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Attempt is a single attempt at an HTTP request, of which there may be several when
+// requests are retried.
+type Attempt struct {
+	Request *http.Request
+	// Number counts from zero for the first attempt, so anything above is a retry.
+	Number int
+	// StatusCode is the status of the response, or zero if there wasn't one.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Retry returns whether this attempt retried an earlier one.
+func (a Attempt) Retry() bool {
+	return a.Number > 0
+}
+
+// Succeeded returns whether the attempt got a successful response.
+func (a Attempt) Succeeded() bool {
+	return a.Err == nil && a.StatusCode > 0 && a.StatusCode < 300
+}
+
+type (
+	requestObserverKey struct{}
+	attemptCounterKey  struct{}
+)
+
+// WithRequestObserver returns a context where every request made with it calls observe
+// after each attempt, including retries. Observers of parent contexts are called too.
+func WithRequestObserver(ctx context.Context, observe func(Attempt)) context.Context {
+	parent, ok := ctx.Value(requestObserverKey{}).(func(Attempt))
+	if !ok {
+		return context.WithValue(ctx, requestObserverKey{}, observe)
+	}
+
+	return context.WithValue(ctx, requestObserverKey{}, func(attempt Attempt) {
+		parent(attempt)
+		observe(attempt)
+	})
+}
+
+// withAttempts gives the request a counter for its attempts, which the retries share as
+// they're made with the same context.
+func withAttempts(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), attemptCounterKey{}, new(atomic.Int64)))
+}
+
+// observeAttempt times each attempt, telling any observer of the request how it went.
+func observeAttempt(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	observe, ok := req.Context().Value(requestObserverKey{}).(func(Attempt))
+	if !ok {
+		return next.RoundTrip(req)
+	}
+
+	number := 0
+	if counter, ok := req.Context().Value(attemptCounterKey{}).(*atomic.Int64); ok {
+		number = int(counter.Add(1) - 1)
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+
+	attempt := Attempt{Request: req, Number: number, Duration: time.Since(start), Err: err}
+	if resp != nil {
+		attempt.StatusCode = resp.StatusCode
+	}
+	observe(attempt)
+
+	return resp, err
+}
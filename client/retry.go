@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryPolicy is how requests that fail with a 429, a 5xx or a connection error are
+// retried.
+type RetryPolicy struct {
+	// Max is how many times to retry a request after the first attempt.
+	Max int
+	// WaitMin and WaitMax bound how long we wait between attempts, doubling each time
+	// unless the API tells us how long to wait with Retry-After.
+	WaitMin, WaitMax time.Duration
+}
+
+// DefaultRetryPolicy waits up to a minute, as the API's rate limits are per minute.
+var DefaultRetryPolicy = RetryPolicy{Max: 3, WaitMin: time.Second, WaitMax: time.Minute}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns a context that clients built with New using it retry requests
+// with the policy, rather than DefaultRetryPolicy.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// newRetryClient builds a client that retries requests as the policy in the context
+// says, making each attempt with transport.
+func newRetryClient(ctx context.Context, transport func(next http.RoundTripper) http.RoundTripper) *retryablehttp.Client {
+	policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	if !ok {
+		policy = DefaultRetryPolicy
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = policy.Max
+	retryClient.RetryWaitMin = policy.WaitMin
+	retryClient.RetryWaitMax = policy.WaitMax
+	retryClient.Backoff = backoff
+	retryClient.HTTPClient.Transport = transport(retryClient.HTTPClient.Transport)
+
+	// Return the last response once we give up, so it can be turned into an error that
+	// includes its body.
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	// Attempts are observed instead, so we don't want the client logging them.
+	retryClient.Logger = nil
+
+	return retryClient
+}
+
+// backoff waits for as long as the API asks with Retry-After, or otherwise doubles the
+// wait after each attempt, never waiting longer than waitMax.
+func backoff(waitMin, waitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	return min(retryablehttp.DefaultBackoff(waitMin, waitMax, attemptNum, resp), waitMax)
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	stdlog "log"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
 	_ "embed"
 
@@ -20,6 +22,8 @@ import (
 	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/tap"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var logger kitlog.Logger
@@ -28,26 +32,44 @@ var (
 	app = kingpin.New("tap-incident", "Extract data from incident.io for use with Singer").Version(versionStanza())
 
 	// Global flags
-	debug         = app.Flag("debug", "Enable debug logging").Default("false").Bool()
+	debug         = app.Flag("debug", "Enable debug logging, the same as --log-level=debug").Default("false").Bool()
+	logFormat     = app.Flag("log-format", "Format of logs written to STDERR, overriding log_format in the config").Enum(config.LogFormats...)
+	logLevel      = app.Flag("log-level", "Least severe level to log, overriding log_level in the config").Enum(config.LogLevels...)
 	configFile    = app.Flag("config", "Configuration file").ExistingFile()
 	catalogFile   = app.Flag("catalog", "If set, allows filtering which streams would be synced").ExistingFile()
+	propertyFile  = app.Flag("properties", "Legacy name for --catalog, as passed by older Singer runners such as Stitch").ExistingFile()
+	stateFile     = app.Flag("state", "State output by the last sync, used to detect deleted records").ExistingFile()
+	about         = app.Flag("about", "If set, describes the tap's capabilities, settings and streams, then exits").Default("false").Bool()
+	aboutFormat   = app.Flag("format", "Format of --about output").Default("json").Enum("json", "markdown")
 	discoveryMode = app.Flag("discover", "If set, only outputs the catalog and exits").Default("false").Bool()
+	strictCatalog = app.Flag("strict-catalog", "If set, fails when the catalog's schema differs from the live schema").Default("false").Bool()
+	validate      = app.Flag("validate-records", "If set, checks every record against its stream's schema, failing if any don't match").Default("false").Bool()
+	outputDir     = app.Flag("output-dir", "If set, writes each stream to a file in this directory rather than outputting to a Singer target").String()
+	outputFormat  = app.Flag("output-format", "Format of the files written to --output-dir").Default("ndjson").Enum("ndjson", "csv")
+	csvNested     = app.Flag("csv-nested", "How nested objects are written to CSV files, either flattened into columns or as JSON").Default("flatten").Enum("flatten", "json")
+	sqlitePath    = app.Flag("sqlite", "If set, writes each stream to a table in this SQLite database rather than outputting to a Singer target").String()
+	metricsAddr   = app.Flag("metrics-addr", "If set, serves Prometheus metrics at /metrics on this address while syncing").String()
+	metricsFile   = app.Flag("metrics-textfile", "If set, writes Prometheus metrics to this file once the sync finishes, for node_exporter or a Pushgateway").String()
 )
 
+// Syncing is what Singer runners expect, so it's what we do unless given a command.
+var _ = app.Command("sync", "Sync data, or discover the catalog with --discover. Runs when no command is given").Default()
+
 func Run(ctx context.Context) (err error) {
-	_, err = app.Parse(os.Args[1:])
+	command, err := app.Parse(os.Args[1:])
 	if err != nil {
 		return err
 	}
 
-	logger = kitlog.NewLogfmtLogger(kitlog.NewSyncWriter(os.Stderr))
-	if *debug {
-		logger = level.NewFilter(logger, level.AllowDebug())
-	} else {
-		logger = level.NewFilter(logger, level.AllowInfo())
+	// Generating a catalog needs no config, so we do it before loading any.
+	if command == catalogCmd.FullCommand() {
+		return generateCatalog()
+	}
+
+	// Describing the tap needs no config, so we do it before loading any.
+	if *about {
+		return printAbout()
 	}
-	logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC, "caller", kitlog.DefaultCaller)
-	stdlog.SetOutput(kitlog.NewStdlibAdapter(logger))
 
 	// Root context to the application.
 	_, cancel := context.WithCancel(context.Background())
@@ -68,10 +90,34 @@ func Run(ctx context.Context) (err error) {
 		return err
 	}
 
+	// The config can set how we log, so we can only build our logger once it's loaded.
+	logger = newLogger(cfg)
+	stdlog.SetOutput(kitlog.NewStdlibAdapter(logger))
+
 	if cfg.Endpoint == "" {
 		cfg.Endpoint = "https://api.incident.io"
 	}
-	cl, err := client.New(ctx, cfg.APIKey, cfg.Endpoint, Version())
+
+	var clientOpts []client.ClientOption
+	if tracingEnabled() {
+		shutdown, err := setupTracing(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Flush any spans we haven't sent, which we'd want most of all if we failed.
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := shutdown(ctx); err != nil {
+				level.Warn(logger).Log("msg", "failed to flush traces", "error", err)
+			}
+		}()
+
+		clientOpts = append(clientOpts, client.WithTracing())
+	}
+
+	cl, err := client.New(ctx, cfg.APIKey, cfg.Endpoint, Version(), clientOpts...)
 	if err != nil {
 		return err
 	}
@@ -79,6 +125,39 @@ func Run(ctx context.Context) (err error) {
 	// Singer requires taps to output to STDOUT. We log to STDERR so the debug log output
 	// can be streamed separately.
 	ol := tap.NewOutputLogger(os.Stdout)
+	if !*discoveryMode {
+		sink, err := buildSink(cfg)
+		if err != nil {
+			return err
+		}
+		if sink != nil {
+			ol = tap.NewSinkOutputLogger(sink)
+		}
+	}
+
+	// Singer metrics go to STDERR alongside our logs, where runners look for them.
+	ol = ol.WithMetrics(os.Stderr)
+
+	if !*discoveryMode && (*metricsAddr != "" || *metricsFile != "") {
+		exporter := tap.NewPrometheusExporter()
+		ol = ol.WithExporter(exporter)
+
+		stop := exportMetrics(exporter)
+
+		// Metrics are most useful when the sync fails, so we export them regardless.
+		defer func() {
+			exporter.Finish(time.Now(), err)
+			if stopErr := stop(); stopErr != nil && err == nil {
+				err = stopErr
+			}
+		}()
+	}
+
+	var validator *tap.RecordValidator
+	if *validate {
+		validator = tap.NewRecordValidator()
+		ol = ol.WithValidator(validator)
+	}
 
 	if *discoveryMode {
 		err = tap.Discover(ctx, logger, ol)
@@ -92,17 +171,63 @@ func Run(ctx context.Context) (err error) {
 			err     error
 		)
 
+		// Older runners pass the catalog as --properties, which we treat the same.
+		if *propertyFile != "" {
+			if *catalogFile != "" && *catalogFile != *propertyFile {
+				return errors.New("--catalog and --properties are the same option, only pass one")
+			}
+			*catalogFile = *propertyFile
+		}
+
 		if *catalogFile != "" {
 			catalog, err = loadCatalogOrError(ctx, *catalogFile)
 			if err != nil {
 				return err
 			}
+
+			// Warn about anything that has changed since the catalog was generated, so
+			// warehouse migrations can be planned before loading.
+			if changes := tap.CatalogDrift(catalog); len(changes) > 0 {
+				for _, change := range changes {
+					level.Warn(logger).Log("msg", "catalog schema differs from live schema", "stream", change.Stream,
+						"kind", change.Kind, "path", change.Path, "from", change.From, "to", change.To)
+				}
+
+				if *strictCatalog {
+					return fmt.Errorf("catalog schema differs from live schema in %d places, regenerate it with catalog --merge", len(changes))
+				}
+			}
+		}
+
+		var state *tap.State
+		if *stateFile != "" {
+			state, err = config.LoadAndParse(*stateFile, tap.State{})
+			if err != nil {
+				return errors.Wrap(err, "loading state")
+			}
 		}
 
-		err = tap.Sync(ctx, logger, ol, cl, catalog)
+		err = tap.Sync(ctx, logger, ol, cl, catalog, state, *cfg)
 		if err != nil {
 			return err
 		}
+
+		if err := ol.Close(); err != nil {
+			return err
+		}
+
+		if validator != nil {
+			if violations := validator.Violations(); len(violations) > 0 {
+				for _, violation := range violations {
+					level.Error(logger).Log("msg", "record does not match schema", "stream", violation.Stream,
+						"kind", violation.Kind, "path", violation.Path, "count", violation.Count, "detail", violation.Detail)
+				}
+
+				return fmt.Errorf("found %d ways in which records did not match their schema", len(violations))
+			}
+
+			level.Info(logger).Log("msg", "every record matched its schema")
+		}
 	}
 
 	return nil
@@ -129,6 +254,46 @@ func versionStanza() string {
 	)
 }
 
+// printAbout describes the tap to STDOUT, for Meltano and plugin registries.
+func printAbout() error {
+	about := tap.NewAbout(Version())
+	if *aboutFormat == "markdown" {
+		fmt.Print(about.Markdown())
+		return nil
+	}
+
+	data, err := json.MarshalIndent(about, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+// buildSink returns where to write data in place of a Singer target, if we've been asked
+// to write it ourselves.
+func buildSink(cfg *config.Config) (tap.Sink, error) {
+	if *outputDir != "" && *sqlitePath != "" {
+		return nil, errors.New("--output-dir can't be combined with --sqlite")
+	}
+	if (*outputDir != "" || *sqlitePath != "") && cfg.BatchConfig != nil {
+		return nil, errors.New("--output-dir and --sqlite can't be combined with batch_config")
+	}
+
+	switch {
+	case *outputDir != "":
+		return tap.NewDirectorySink(*outputDir, tap.DirectorySinkOptions{
+			Format:       tap.FileFormat(*outputFormat),
+			NestedAsJSON: *csvNested == "json",
+		})
+	case *sqlitePath != "":
+		return tap.NewSQLiteSink(*sqlitePath)
+	default:
+		return nil, nil
+	}
+}
+
 func loadCatalogOrError(ctx context.Context, catalogFile string) (catalog *tap.Catalog, err error) {
 	defer func() {
 		if err == nil {
@@ -162,40 +327,27 @@ func loadConfigOrError(ctx context.Context, configFile string) (cfg *config.Conf
    export INCIDENT_API_KEY="<your-api-key>"
    export INCIDENT_ENDPOINT="https://api.incident.io" (optional)
 
-2. Config file in JSON format:
+2. Config file in JSON or YAML format:
    {
      "api_key": "<your-api-key>",
      "endpoint": "<api-endpoint>" (optional)
    }
+
+3. TAP_INCIDENT_ environment variables for any setting, such as:
+   export TAP_INCIDENT_API_KEY="<your-api-key>"
 `)
 	}()
 
-	// Try to load from environment variables first
-	cfg = &config.Config{
-		APIKey:   os.Getenv("INCIDENT_API_KEY"),
-		Endpoint: os.Getenv("INCIDENT_ENDPOINT"),
-	}
-
-	// If a config file is provided, load it and merge with env vars
-	if configFile != "" {
-		fileCfg, err := config.LoadAndParse(configFile, config.Config{})
-		if err != nil {
-			return nil, errors.Wrap(err, "loading config")
-		}
-		
-		// File config takes precedence over env vars
-		if fileCfg.APIKey != "" {
-			cfg.APIKey = fileCfg.APIKey
-		}
-		if fileCfg.Endpoint != "" {
-			cfg.Endpoint = fileCfg.Endpoint
-		}
+	// See config.Load for where settings come from, and which take precedence
+	cfg, err = config.Load(configFile, os.Environ())
+	if err != nil {
+		return nil, errors.Wrap(err, "loading config")
 	}
 
-	// Validate the final config
+	// Validate the final config, once every source of settings has been applied
 	if err := cfg.Validate(); err != nil {
 		if configFile == "" && cfg.APIKey == "" {
-			return nil, errors.New("No API key provided. Set INCIDENT_API_KEY environment variable or use --config flag")
+			return nil, errors.New("No API key provided. Set the INCIDENT_API_KEY or TAP_INCIDENT_API_KEY environment variable or use --config flag")
 		}
 		data, _ := json.MarshalIndent(err, "", "  ")
 
@@ -206,6 +358,42 @@ func loadConfigOrError(ctx context.Context, configFile string) (cfg *config.Conf
 	return cfg, nil
 }
 
+// exportMetrics starts serving metrics if --metrics-addr is set, returning a function to
+// call once the sync has finished that stops the server and writes --metrics-textfile.
+func exportMetrics(exporter *tap.PrometheusExporter) (stop func() error) {
+	var server *http.Server
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(exporter.Registry(), promhttp.HandlerOpts{}))
+
+		server = &http.Server{Addr: *metricsAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				level.Error(logger).Log("msg", "serving metrics failed", "error", err)
+			}
+		}()
+	}
+
+	return func() error {
+		if server != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := server.Shutdown(ctx); err != nil {
+				return errors.Wrap(err, "stopping metrics server")
+			}
+		}
+
+		if *metricsFile != "" {
+			if err := prometheus.WriteToTextfile(*metricsFile, exporter.Registry()); err != nil {
+				return errors.Wrap(err, "writing metrics")
+			}
+		}
+
+		return nil
+	}
+}
+
 // OUT prints progress output to stderr.
 func OUT(msg string, args ...any) {
 	fmt.Fprintf(os.Stderr, msg+"\n", args...)
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/pkg/errors"
+)
+
+var (
+	catalogCmd      = app.Command("catalog", "Discover the catalog, applying selections so it's ready to use with --catalog")
+	catalogSelect   = catalogCmd.Flag("select", "Select a stream or field, such as incidents or incidents.*, which can be repeated").PlaceHolder("PATTERN").Strings()
+	catalogDeselect = catalogCmd.Flag("deselect", "Deselect a stream or field, such as users.email, which can be repeated").PlaceHolder("PATTERN").Strings()
+	catalogExclude  = catalogCmd.Flag("exclude", "Leave a stream out of the catalog, such as alerts, which can be repeated").PlaceHolder("PATTERN").Strings()
+	catalogMerge    = catalogCmd.Flag("merge", "Keep the selections of this existing catalog, updating it to the live schema").ExistingFile()
+	catalogOutput   = catalogCmd.Flag("output", "Write the catalog to this file rather than STDOUT, which may be the file given to --merge").Short('o').String()
+)
+
+// generateCatalog discovers the catalog and applies the selections we were given, so
+// catalogs can be kept reproducible rather than edited by hand.
+func generateCatalog() error {
+	catalog := tap.DefaultCatalog()
+
+	if *catalogMerge != "" {
+		existing, err := config.LoadAndParse(*catalogMerge, tap.Catalog{})
+		if err != nil {
+			return errors.Wrap(err, "loading catalog to merge")
+		}
+
+		catalog.Merge(existing)
+	}
+
+	err := catalog.Apply(tap.CatalogSelection{
+		Select:   *catalogSelect,
+		Deselect: *catalogDeselect,
+		Exclude:  *catalogExclude,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if *catalogOutput == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(*catalogOutput, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "writing catalog")
+	}
+
+	return nil
+}
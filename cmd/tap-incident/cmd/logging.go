@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/incident-io/singer-tap/config"
+)
+
+// newLogger builds the logger we write to STDERR, where flags take precedence over the
+// config. Logs without a level are always written.
+func newLogger(cfg *config.Config) kitlog.Logger {
+	format := cfg.LogFormat
+	if *logFormat != "" {
+		format = *logFormat
+	}
+
+	lvl := cfg.LogLevel
+	if *logLevel != "" {
+		lvl = *logLevel
+	} else if *debug {
+		lvl = "debug"
+	}
+
+	var logger kitlog.Logger
+	if format == "json" {
+		logger = kitlog.NewJSONLogger(kitlog.NewSyncWriter(os.Stderr))
+	} else {
+		logger = kitlog.NewLogfmtLogger(kitlog.NewSyncWriter(os.Stderr))
+	}
+
+	switch lvl {
+	case "debug":
+		logger = level.NewFilter(logger, level.AllowDebug())
+	case "warn":
+		logger = level.NewFilter(logger, level.AllowWarn())
+	case "error":
+		logger = level.NewFilter(logger, level.AllowError())
+	default:
+		logger = level.NewFilter(logger, level.AllowInfo())
+	}
+
+	return kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC, "caller", kitlog.DefaultCaller)
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+)
+
+// BatchConfig has records written to files, with BATCH messages referencing them output
+// in place of RECORD messages. This follows the batch_config of the Singer SDK, so any
+// target built on the SDK can read them.
+type BatchConfig struct {
+	Encoding BatchEncoding `json:"encoding"`
+	Storage  BatchStorage  `json:"storage"`
+	// BatchSize is the most records we write to a single file. Defaults to
+	// DefaultBatchSize.
+	BatchSize int `json:"batch_size,omitempty"`
+}
+
+// DefaultBatchSize keeps each file to a few megabytes once compressed.
+const DefaultBatchSize = 10000
+
+type BatchEncoding struct {
+	// Format is the format of each file, which can only be "jsonl".
+	Format string `json:"format"`
+	// Compression is either "gzip" or "none". Defaults to "gzip".
+	Compression string `json:"compression,omitempty"`
+}
+
+type BatchStorage struct {
+	// Root is the directory to write files to, either as a path or a file:// URI.
+	Root string `json:"root"`
+	// Prefix is prepended to the name of each file.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+var (
+	BatchFormatJSONL     = "jsonl"
+	BatchCompressionGzip = "gzip"
+	BatchCompressionNone = "none"
+)
+
+func (c BatchConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Encoding),
+		validation.Field(&c.Storage),
+		validation.Field(&c.BatchSize, validation.Min(1)),
+	)
+}
+
+func (e BatchEncoding) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Format, validation.Required, validation.In(BatchFormatJSONL)),
+		validation.Field(&e.Compression, validation.In(BatchCompressionGzip, BatchCompressionNone)),
+	)
+}
+
+func (s BatchStorage) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Root, validation.Required, validation.By(func(value any) error {
+			if _, err := s.Dir(); err != nil {
+				return err
+			}
+
+			return nil
+		})),
+	)
+}
+
+// Dir returns the local directory files should be written to.
+func (s BatchStorage) Dir() (string, error) {
+	root, err := url.Parse(s.Root)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing root")
+	}
+
+	switch root.Scheme {
+	case "":
+		return s.Root, nil
+	case "file":
+		if root.Host != "" && root.Host != "localhost" {
+			return "", errors.New("must be a local directory or file:// URI")
+		}
+
+		return root.Path, nil
+	default:
+		return "", errors.New("must be a local directory or file:// URI")
+	}
+}
+
+// Size returns how many records to write to each file.
+func (c BatchConfig) Size() int {
+	if c.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+
+	return c.BatchSize
+}
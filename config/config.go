@@ -2,16 +2,70 @@ package config
 
 import (
 	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 type Config struct {
 	APIKey   string `json:"api_key,omitempty"`
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// LogFormat is how logs are written to STDERR, either logfmt (the default) or json.
+	LogFormat string `json:"log_format,omitempty"`
+	// LogLevel is the least severe level that's logged, defaulting to info.
+	LogLevel string `json:"log_level,omitempty"`
+
+	// IncidentModes are the modes of incident to extract, applied to the incidents,
+	// follow-ups and incident updates streams. Defaults to DefaultIncidentModes.
+	IncidentModes []string `json:"incident_modes,omitempty"`
+
+	// Streams customises how each stream is synced, keyed by stream name.
+	Streams map[string]StreamConfig `json:"streams,omitempty"`
+
+	// FlatteningEnabled unnests nested objects into top-level properties, so
+	// severity.name becomes severity__name, for targets that would otherwise store them
+	// as JSON.
+	FlatteningEnabled bool `json:"flattening_enabled,omitempty"`
+	// FlatteningMaxDepth limits how many levels of nesting are flattened. Unlimited if
+	// unset.
+	FlatteningMaxDepth int `json:"flattening_max_depth,omitempty"`
+
+	// Transforms change the value of fields in every record, applied in order.
+	Transforms []Transform `json:"transforms,omitempty"`
+	// TransformPresets add transforms for common needs, e.g. "pii".
+	TransformPresets []string `json:"transform_presets,omitempty"`
+	// HashKey is the secret used to hash fields, needed if any transform hashes them.
+	HashKey string `json:"hash_key,omitempty"`
+
+	// BatchConfig, if set, writes records to files referenced by BATCH messages rather
+	// than outputting each as a RECORD message.
+	BatchConfig *BatchConfig `json:"batch_config,omitempty"`
 }
 
 func (c Config) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.APIKey, validation.Required.
 			Error("must provide an api_key to authenticate against the incident.io API.")),
+		validation.Field(&c.LogFormat, validation.In(lo.ToAnySlice(LogFormats)...)),
+		validation.Field(&c.LogLevel, validation.In(lo.ToAnySlice(LogLevels)...)),
+		validation.Field(&c.IncidentModes, validation.Each(validation.In(lo.ToAnySlice(IncidentModes)...))),
+		validation.Field(&c.Streams, validation.By(validateStreams), validation.By(func(value any) error {
+			if len(c.IncidentModes) > 0 && c.Stream("incidents").Filters != nil && c.Stream("incidents").Filters.Mode != nil {
+				return errors.New("incidents: filters: mode cannot be combined with incident_modes")
+			}
+
+			return nil
+		})),
+		validation.Field(&c.FlatteningMaxDepth, validation.Min(1), validation.By(func(value any) error {
+			if value.(int) != 0 && !c.FlatteningEnabled {
+				return errors.New("requires flattening_enabled")
+			}
+
+			return nil
+		})),
+		validation.Field(&c.Transforms),
+		validation.Field(&c.TransformPresets, validation.Each(validation.In(lo.ToAnySlice(TransformPresets)...))),
+		validation.Field(&c.HashKey, validation.By(c.validateHashKey)),
+		validation.Field(&c.BatchConfig),
 	)
 }
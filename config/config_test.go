@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"time"
+
 	"github.com/incident-io/singer-tap/config"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -22,5 +24,256 @@ var _ = Describe("Config", func() {
 		It("should validate", func() {
 			Expect(cfg.Validate()).To(Succeed())
 		})
+
+		Describe("logging", func() {
+			It("accepts known formats and levels", func() {
+				cfg.LogFormat = "json"
+				cfg.LogLevel = "warn"
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects unknown formats and levels", func() {
+				cfg.LogFormat = "xml"
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("log_format")))
+
+				cfg.LogFormat = ""
+				cfg.LogLevel = "verbose"
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("log_level")))
+			})
+		})
+
+		Describe("streams", func() {
+			It("accepts page sizes within the API maximum", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"alerts": {PageSize: 50, MaxPages: 2, Timeout: config.Duration(time.Second)},
+				}
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects page sizes above the API maximum", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"alerts": {PageSize: 51},
+				}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("must be no greater than 50")))
+			})
+
+			It("rejects page sizes for unpaginated streams", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"severities": {PageSize: 10},
+				}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("not paginated")))
+			})
+		})
+
+		Describe("soft delete", func() {
+			It("accepts full-table streams", func() {
+				cfg.Streams = map[string]config.StreamConfig{"severities": {SoftDelete: true}}
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects other streams", func() {
+				cfg.Streams = map[string]config.StreamConfig{"incidents": {SoftDelete: true}}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("only be set for full-table streams")))
+			})
+
+			It("rejects max pages, which would mark records that weren't loaded as deleted", func() {
+				cfg.Streams = map[string]config.StreamConfig{"custom_field_options": {SoftDelete: true, MaxPages: 1}}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("cannot be set for full-table streams")))
+			})
+		})
+
+		Describe("incident metric durations", func() {
+			It("accepts durations for the incident_metrics stream", func() {
+				cfg.Streams = map[string]config.StreamConfig{"incident_metrics": {Durations: []config.TimestampDuration{
+					{Name: "time_to_fix", From: "Reported at", To: "Fixed at"},
+				}}}
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects incomplete or repeated durations", func() {
+				cfg.Streams = map[string]config.StreamConfig{"incident_metrics": {Durations: []config.TimestampDuration{
+					{Name: "time_to_fix", From: "Reported at"},
+				}}}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("to: cannot be blank")))
+
+				cfg.Streams = map[string]config.StreamConfig{"incident_metrics": {Durations: []config.TimestampDuration{
+					{Name: "time_to_fix", From: "Reported at", To: "Fixed at"},
+					{Name: "time_to_fix", From: "Reported at", To: "Resolved at"},
+				}}}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("time_to_fix is configured more than once")))
+			})
+
+			It("rejects durations for other streams", func() {
+				cfg.Streams = map[string]config.StreamConfig{"incidents": {Durations: []config.TimestampDuration{
+					{Name: "time_to_fix", From: "Reported at", To: "Fixed at"},
+				}}}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("only be set for the incident_metrics stream")))
+			})
+		})
+
+		Describe("flattening", func() {
+			It("accepts a max depth when enabled", func() {
+				cfg.FlatteningEnabled = true
+				cfg.FlatteningMaxDepth = 2
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects a max depth without flattening", func() {
+				cfg.FlatteningMaxDepth = 2
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("requires flattening_enabled")))
+			})
+		})
+
+		Describe("transforms", func() {
+			It("accepts known actions and presets with a hash key", func() {
+				cfg.Transforms = []config.Transform{
+					{Path: "summary", Action: config.TransformDrop, Streams: []string{"incidents"}},
+					{Path: "**.email", Action: config.TransformHash},
+				}
+				cfg.TransformPresets = []string{"pii"}
+				cfg.HashKey = "secret"
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects unknown actions and presets", func() {
+				cfg.Transforms = []config.Transform{{Path: "summary", Action: "encrypt"}}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("action")))
+
+				cfg.Transforms = nil
+				cfg.TransformPresets = []string{"phi"}
+				cfg.HashKey = "secret"
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("transform_presets")))
+			})
+
+			It("requires a hash key to hash fields", func() {
+				cfg.TransformPresets = []string{"pii"}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("must be set to hash fields")))
+			})
+		})
+
+		Describe("batch config", func() {
+			BeforeEach(func() {
+				cfg.BatchConfig = &config.BatchConfig{
+					Encoding: config.BatchEncoding{Format: "jsonl"},
+					Storage:  config.BatchStorage{Root: "file:///tmp/batches"},
+				}
+			})
+
+			It("accepts local directories", func() {
+				Expect(cfg.Validate()).To(Succeed())
+
+				cfg.BatchConfig.Storage.Root = "batches"
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects remote storage", func() {
+				cfg.BatchConfig.Storage.Root = "s3://bucket/batches"
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("must be a local directory")))
+			})
+
+			It("rejects unsupported encodings", func() {
+				cfg.BatchConfig.Encoding = config.BatchEncoding{Format: "parquet", Compression: "zstd"}
+				Expect(cfg.Validate()).To(MatchError(SatisfyAll(
+					ContainSubstring("format: must be a valid value"),
+					ContainSubstring("compression: must be a valid value"),
+				)))
+			})
+
+			It("returns the directory of file URIs", func() {
+				Expect(cfg.BatchConfig.Storage.Dir()).To(Equal("/tmp/batches"))
+			})
+		})
+
+		Describe("incident modes", func() {
+			It("accepts known modes", func() {
+				cfg.IncidentModes = []string{"standard", "test"}
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects unknown modes", func() {
+				cfg.IncidentModes = []string{"practice"}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("incident_modes")))
+			})
+
+			It("rejects also filtering incidents by mode", func() {
+				cfg.IncidentModes = []string{"test"}
+				cfg.Streams = map[string]config.StreamConfig{
+					"incidents": {Filters: &config.IncidentFilters{Mode: config.Filter{"one_of": {"standard"}}}},
+				}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("cannot be combined with incident_modes")))
+			})
+		})
+
+		Describe("incident filters", func() {
+			It("accepts supported operators and values", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"incidents": {Filters: &config.IncidentFilters{
+						StatusCategory: config.Filter{"one_of": {"live", "closed"}},
+						Mode:           config.Filter{"one_of": {"standard", "test"}},
+						CustomField:    map[string]config.Filter{"01FCNDV6P870EA6S7TK1DSYDG0": {"one_of": {"option"}}},
+					}},
+				}
+				Expect(cfg.Validate()).To(Succeed())
+			})
+
+			It("rejects unsupported operators", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"incidents": {Filters: &config.IncidentFilters{
+						IncidentRole: map[string]config.Filter{"01FH5TZRWMNAFB0DZ23FD1TV96": {"gte": {"x"}}},
+					}},
+				}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring(`unsupported operator "gte"`)))
+			})
+
+			It("rejects unknown modes", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"incidents": {Filters: &config.IncidentFilters{
+						Mode: config.Filter{"one_of": {"practice"}},
+					}},
+				}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring(`unsupported value "practice"`)))
+			})
+
+			It("rejects filters on other streams", func() {
+				cfg.Streams = map[string]config.StreamConfig{
+					"alerts": {Filters: &config.IncidentFilters{}},
+				}
+				Expect(cfg.Validate()).To(MatchError(ContainSubstring("only be set for the incidents stream")))
+			})
+		})
+	})
+
+	Describe("Settings", func() {
+		It("describes every setting", func() {
+			settings := config.Settings()
+			Expect(settings.Properties).To(HaveKey("api_key"))
+			for name, setting := range settings.Properties {
+				Expect(setting.Description).NotTo(BeEmpty(), name)
+			}
+		})
+
+		It("includes the constraints that are validated", func() {
+			settings := config.Settings()
+			Expect(settings.Required).To(Equal([]string{"api_key"}))
+			Expect(settings.Properties["api_key"].Secret).To(BeTrue())
+			Expect(settings.Properties["log_level"].Enum).To(Equal([]any{"debug", "info", "warn", "error"}))
+			Expect(settings.Properties["incident_modes"].Items.Enum).To(ContainElement("retrospective"))
+			Expect(settings.Properties["streams"].AdditionalProperties.Properties["timeout"].Type).To(Equal([]string{"string"}))
+			Expect(settings.Properties["transforms"].Items.Properties["action"].Enum).To(ContainElement("hash"))
+		})
+	})
+
+	Describe("ParseContents", func() {
+		It("parses stream timeouts as durations", func() {
+			cfg, err := config.ParseContents([]byte(`{
+				"api_key": "an-api-key",
+				"streams": {"incidents": {"page_size": 100, "timeout": "1m30s"}}
+			}`), config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Stream("incidents")).To(Equal(config.StreamConfig{
+				PageSize: 100,
+				Timeout:  config.Duration(90 * time.Second),
+			}))
+		})
 	})
 })
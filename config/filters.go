@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// Filter maps an API filter operator to the values it applies to, for example:
+//
+//	{"one_of": ["live", "closed"]}
+type Filter map[string][]string
+
+// IncidentFilters are passed to the incidents API so only matching incidents are
+// loaded. Each maps directly onto a filter parameter of the list incidents endpoint.
+type IncidentFilters struct {
+	// Status filters on incident status IDs.
+	Status Filter `json:"status,omitempty"`
+	// StatusCategory filters on status categories, e.g. "live" or "closed".
+	StatusCategory Filter `json:"status_category,omitempty"`
+	// Severity filters on severity IDs.
+	Severity Filter `json:"severity,omitempty"`
+	// IncidentType filters on incident type IDs.
+	IncidentType Filter `json:"incident_type,omitempty"`
+	// IncidentRole filters on who holds a role, keyed by incident role ID.
+	IncidentRole map[string]Filter `json:"incident_role,omitempty"`
+	// CustomField filters on custom field values, keyed by custom field ID.
+	CustomField map[string]Filter `json:"custom_field,omitempty"`
+	// Mode filters on incident mode. The API defaults to standard and retrospective
+	// incidents, so this is how you opt in to test and tutorial incidents.
+	Mode Filter `json:"mode,omitempty"`
+}
+
+// Validate checks each filter uses operators and values the API accepts.
+func (f IncidentFilters) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Status, validation.By(operators("one_of", "not_in"))),
+		validation.Field(&f.StatusCategory, validation.By(operators("one_of", "not_in")),
+			validation.By(values(lo.Map([]client.IncidentStatusV2Category{
+				client.IncidentStatusV2CategoryTriage,
+				client.IncidentStatusV2CategoryDeclined,
+				client.IncidentStatusV2CategoryMerged,
+				client.IncidentStatusV2CategoryCanceled,
+				client.IncidentStatusV2CategoryLive,
+				client.IncidentStatusV2CategoryLearning,
+				client.IncidentStatusV2CategoryClosed,
+				client.IncidentStatusV2CategoryPaused,
+			}, func(category client.IncidentStatusV2Category, _ int) string {
+				return string(category)
+			})...))),
+		validation.Field(&f.Severity, validation.By(operators("one_of", "not_in", "gte", "lte"))),
+		validation.Field(&f.IncidentType, validation.By(operators("one_of", "not_in"))),
+		validation.Field(&f.IncidentRole, validation.By(each(operators("one_of", "is_blank")))),
+		validation.Field(&f.CustomField, validation.By(each(operators()))),
+		validation.Field(&f.Mode, validation.By(operators("one_of")), validation.By(values(IncidentModes...))),
+	)
+}
+
+// IncidentModes are the modes an incident can be in.
+var IncidentModes = []string{
+	string(client.IncidentV2ModeStandard),
+	string(client.IncidentV2ModeRetrospective),
+	string(client.IncidentV2ModeTest),
+	string(client.IncidentV2ModeTutorial),
+}
+
+// DefaultIncidentModes are the modes the API returns when none are asked for, which
+// leaves out test and tutorial incidents.
+var DefaultIncidentModes = []string{
+	string(client.IncidentV2ModeStandard),
+	string(client.IncidentV2ModeRetrospective),
+}
+
+// Modes returns the incident modes to extract, falling back to the API default.
+func (c Config) Modes() []string {
+	if len(c.IncidentModes) > 0 {
+		return c.IncidentModes
+	}
+
+	return DefaultIncidentModes
+}
+
+// IncidentFilters returns the filters for the incidents stream, restricted to the
+// configured incident modes unless the stream filters on mode itself.
+func (c Config) IncidentFilters() IncidentFilters {
+	var filters IncidentFilters
+	if configured := c.Stream("incidents").Filters; configured != nil {
+		filters = *configured
+	}
+	if filters.Mode == nil {
+		filters.Mode = Filter{"one_of": c.Modes()}
+	}
+
+	return filters
+}
+
+// operators returns a rule that checks a filter only uses the given operators, or any
+// operator if none are given, and that each operator has values.
+func operators(allowed ...string) validation.RuleFunc {
+	return func(value any) error {
+		for operator, values := range value.(Filter) {
+			if len(allowed) > 0 && !lo.Contains(allowed, operator) {
+				return fmt.Errorf("unsupported operator %q, must be one of: %v", operator, allowed)
+			}
+			if len(values) == 0 {
+				return fmt.Errorf("operator %q must have at least one value", operator)
+			}
+		}
+
+		return nil
+	}
+}
+
+// values returns a rule that checks a filter only matches against the given values.
+func values(allowed ...string) validation.RuleFunc {
+	return func(value any) error {
+		for _, values := range value.(Filter) {
+			for _, v := range values {
+				if !lo.Contains(allowed, v) {
+					return fmt.Errorf("unsupported value %q, must be one of: %v", v, allowed)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// each applies a rule to every filter in a map of filters, such as those keyed by
+// custom field ID.
+func each(rule validation.RuleFunc) validation.RuleFunc {
+	return func(value any) error {
+		filters := value.(map[string]Filter)
+
+		keys := lo.Keys(filters)
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := rule(filters[key]); err != nil {
+				return errors.Wrap(err, key)
+			}
+		}
+
+		return nil
+	}
+}
@@ -2,15 +2,23 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/invopop/yaml"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
+// LoadAndParse reads a JSON file, or a YAML file if it has a .yaml or .yml extension.
 func LoadAndParse[T any](path string, obj T) (*T, error) {
-	b, err := os.ReadFile(path)
+	b, err := readFile(path)
 	if err != nil {
-		return &obj, errors.Wrapf(err, "unable to read file at path %v", path)
+		return &obj, err
 	}
 
 	return ParseContents(b, obj)
@@ -22,3 +30,264 @@ func ParseContents[T any](content []byte, obj T) (*T, error) {
 	}
 	return &obj, nil
 }
+
+// readFile returns the contents of a JSON or YAML file as JSON, so either can be parsed
+// with the json tags of our types.
+func readFile(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file at path %v", path)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		b, err = yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing YAML at path %v", path)
+		}
+	}
+
+	return b, nil
+}
+
+// EnvPrefix starts the name of the environment variable for each setting, such as
+// TAP_INCIDENT_LOG_LEVEL for log_level.
+const EnvPrefix = "TAP_INCIDENT_"
+
+// EnvSeparator separates the names of nested settings in an environment variable, such
+// as TAP_INCIDENT_STREAMS__INCIDENTS__PAGE_SIZE for streams.incidents.page_size.
+const EnvSeparator = "__"
+
+// Load builds the config from the environment, given as "NAME=value" pairs like
+// os.Environ, and the config file at path, if given, which may be JSON or YAML. Settings
+// are taken from, in increasing precedence:
+//
+//  1. INCIDENT_API_KEY and INCIDENT_ENDPOINT, as we've always supported.
+//  2. The config file, where "${VAR}" in any string is replaced by the value of the
+//     environment variable, or "${VAR:-default}" if it might not be set. Strings with
+//     references are then parsed by the type of their setting, so "${PAGE_SIZE}" can
+//     set a number. Empty strings and nulls leave the setting unset.
+//  3. An environment variable for any setting, named by EnvPrefix and EnvSeparator,
+//     which replaces its value unless empty while keeping any others around it. Those
+//     for a setting are applied before those nested within it. Numbers, booleans,
+//     objects and arrays are given as JSON, though arrays of strings can be
+//     comma-separated. Variables that don't name a setting are ignored.
+//
+// This lets secrets come only from the environment, while the rest of the config is
+// templated, such as by Helm. The config isn't validated, which is left to the caller.
+func Load(path string, environ []string) (*Config, error) {
+	env := map[string]string{}
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		env[name] = value
+	}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	settings := map[string]any{}
+
+	for name, variable := range map[string]string{"api_key": "INCIDENT_API_KEY", "endpoint": "INCIDENT_ENDPOINT"} {
+		if value, _ := lookupEnv(variable); value != "" {
+			settings[name] = value
+		}
+	}
+
+	if path != "" {
+		b, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var file map[string]any
+		if err := json.Unmarshal(b, &file); err != nil {
+			return nil, errors.Wrap(err, "parsing config")
+		}
+
+		interpolated, err := interpolate("", file, Settings(), lookupEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range interpolated.(map[string]any) {
+			if value != nil && value != "" {
+				settings[name] = value
+			}
+		}
+	}
+
+	names := lo.Filter(lo.Keys(env), func(name string, _ int) bool {
+		return strings.HasPrefix(name, EnvPrefix) && env[name] != ""
+	})
+	sort.Slice(names, func(i, j int) bool {
+		depthI, depthJ := strings.Count(names[i], EnvSeparator), strings.Count(names[j], EnvSeparator)
+		if depthI != depthJ {
+			return depthI < depthJ
+		}
+
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		keys := strings.Split(strings.ToLower(strings.TrimPrefix(name, EnvPrefix)), EnvSeparator)
+
+		schema := envSettingSchema(Settings(), keys)
+		if schema == nil {
+			continue
+		}
+
+		parsed, err := parseEnvSetting(env[name], schema)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", name)
+		}
+
+		setSetting(settings, keys, parsed)
+	}
+
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ParseContents(b, Config{})
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing config")
+	}
+
+	return cfg, nil
+}
+
+// envSettingSchema finds the schema of the setting named by the keys of an environment
+// variable, or nil if it doesn't name one. Keys can name properties of objects, or the
+// keys of maps such as the name of a stream, but not the items of arrays.
+func envSettingSchema(schema *SettingSchema, keys []string) *SettingSchema {
+	for _, key := range keys {
+		switch {
+		case schema.Properties[key] != nil:
+			schema = schema.Properties[key]
+		case schema.AdditionalProperties != nil && key != "":
+			schema = schema.AdditionalProperties
+		default:
+			return nil
+		}
+	}
+
+	return schema
+}
+
+// setSetting sets the value at the keys, creating any objects along the way and
+// replacing anything that isn't one.
+func setSetting(settings map[string]any, keys []string, value any) {
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := settings[key].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			settings[key] = child
+		}
+
+		settings = child
+	}
+
+	settings[keys[len(keys)-1]] = value
+}
+
+// references matches "$$", which escapes a "$", and "${VAR}" or "${VAR:-default}".
+var references = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolate replaces references to environment variables in every string of the
+// parsed config, returning an error for any that aren't set and have no default. Strings
+// with references are parsed by the type of their setting, as in the environment.
+func interpolate(path string, value any, schema *SettingSchema, lookupEnv func(string) (string, bool)) (any, error) {
+	switch value := value.(type) {
+	case string:
+		var missing []string
+		result := references.ReplaceAllStringFunc(value, func(reference string) string {
+			if reference == "$$" {
+				return "$"
+			}
+
+			match := references.FindStringSubmatch(reference)
+			if env, ok := lookupEnv(match[1]); ok {
+				return env
+			}
+			if match[2] != "" {
+				return match[3]
+			}
+
+			missing = append(missing, match[1])
+			return ""
+		})
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("config references environment variables that aren't set: %s", strings.Join(missing, ", "))
+		}
+
+		if schema == nil || result == value || result == "" {
+			return result, nil
+		}
+
+		parsed, err := parseEnvSetting(result, schema)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s after interpolating", path)
+		}
+
+		return parsed, nil
+	case map[string]any:
+		result := map[string]any{}
+		for key, item := range value {
+			var child *SettingSchema
+			if schema != nil {
+				child = schema.Properties[key]
+				if child == nil {
+					child = schema.AdditionalProperties
+				}
+			}
+
+			interpolated, err := interpolate(joinSettingPath(path, key), item, child, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = interpolated
+		}
+
+		return result, nil
+	case []any:
+		var items *SettingSchema
+		if schema != nil {
+			items = schema.Items
+		}
+
+		result := []any{}
+		for _, item := range value {
+			interpolated, err := interpolate(path+"[]", item, items, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, interpolated)
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// parseEnvSetting parses the value of a setting's environment variable by its type.
+func parseEnvSetting(value string, schema *SettingSchema) (any, error) {
+	switch {
+	case lo.Contains(schema.Type, "string"):
+		return value, nil
+	case lo.Contains(schema.Type, "array") && schema.Items != nil && lo.Contains(schema.Items.Type, "string") &&
+		!strings.HasPrefix(strings.TrimSpace(value), "["):
+		return lo.Map(strings.Split(value, ","), func(item string, _ int) string {
+			return strings.TrimSpace(item)
+		}), nil
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Load", func() {
+	var (
+		env map[string]string
+	)
+
+	BeforeEach(func() {
+		env = map[string]string{}
+	})
+
+	environ := func() []string {
+		return lo.MapToSlice(env, func(name, value string) string {
+			return name + "=" + value
+		})
+	}
+
+	write := func(name, contents string) string {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+
+		return path
+	}
+
+	It("loads JSON and YAML config files", func() {
+		for _, path := range []string{
+			write("config.json", `{"api_key": "an-api-key", "streams": {"alerts": {"page_size": 25, "timeout": "30s"}}}`),
+			write("config.yaml", "api_key: an-api-key\nstreams:\n  alerts:\n    page_size: 25\n    timeout: 30s\n"),
+		} {
+			cfg, err := config.Load(path, environ())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.APIKey).To(Equal("an-api-key"))
+			Expect(cfg.Streams).To(Equal(map[string]config.StreamConfig{
+				"alerts": {PageSize: 25, Timeout: config.Duration(30 * time.Second)},
+			}))
+		}
+	})
+
+	It("interpolates environment variables into strings", func() {
+		env["API_KEY"] = "an-api-key"
+		path := write("config.yml", `
+api_key: ${API_KEY}
+hash_key: prefix-$${LITERAL}
+log_level: ${LOG_LEVEL:-warn}
+incident_modes: ["${MODE:-standard}"]
+`)
+
+		cfg, err := config.Load(path, environ())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.APIKey).To(Equal("an-api-key"))
+		Expect(cfg.HashKey).To(Equal("prefix-${LITERAL}"))
+		Expect(cfg.LogLevel).To(Equal("warn"))
+		Expect(cfg.IncidentModes).To(Equal([]string{"standard"}))
+	})
+
+	It("parses interpolated values by the type of their setting", func() {
+		env["PAGE_SIZE"] = "25"
+		env["SOFT_DELETE"] = "true"
+		path := write("config.yaml", `
+flattening_enabled: ${FLATTENING_ENABLED:-false}
+flattening_max_depth: ${FLATTENING_MAX_DEPTH:-}
+incident_modes: ${INCIDENT_MODES:-standard,retrospective}
+streams:
+  custom_fields:
+    page_size: ${PAGE_SIZE}
+    soft_delete: ${SOFT_DELETE}
+`)
+
+		cfg, err := config.Load(path, environ())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.FlatteningEnabled).To(BeFalse())
+		Expect(cfg.FlatteningMaxDepth).To(BeZero())
+		Expect(cfg.IncidentModes).To(Equal([]string{"standard", "retrospective"}))
+		Expect(cfg.Streams).To(Equal(map[string]config.StreamConfig{
+			"custom_fields": {PageSize: 25, SoftDelete: true},
+		}))
+	})
+
+	It("returns an error for interpolated values that don't parse", func() {
+		env["PAGE_SIZE"] = "lots"
+		path := write("config.yaml", "streams:\n  users:\n    page_size: ${PAGE_SIZE}\n")
+
+		_, err := config.Load(path, environ())
+		Expect(err).To(MatchError(ContainSubstring("parsing streams.users.page_size after interpolating")))
+	})
+
+	It("returns an error for variables that aren't set", func() {
+		path := write("config.yaml", "api_key: ${API_KEY}\n")
+
+		_, err := config.Load(path, environ())
+		Expect(err).To(MatchError(ContainSubstring("aren't set: API_KEY")))
+	})
+
+	It("prefers TAP_INCIDENT_ variables to the file, and the file to INCIDENT_ variables", func() {
+		env["INCIDENT_API_KEY"] = "legacy-api-key"
+		env["INCIDENT_ENDPOINT"] = "https://legacy.example.com"
+		env["TAP_INCIDENT_API_KEY"] = "an-api-key"
+		env["TAP_INCIDENT_LOG_LEVEL"] = ""
+		path := write("config.yaml", "endpoint: https://api.example.com\nlog_level: debug\n")
+
+		cfg, err := config.Load(path, environ())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.APIKey).To(Equal("an-api-key"))
+		Expect(cfg.Endpoint).To(Equal("https://api.example.com"))
+		Expect(cfg.LogLevel).To(Equal("debug"))
+	})
+
+	It("parses TAP_INCIDENT_ variables by the type of their setting", func() {
+		env["TAP_INCIDENT_API_KEY"] = "12345"
+		env["TAP_INCIDENT_FLATTENING_ENABLED"] = "true"
+		env["TAP_INCIDENT_FLATTENING_MAX_DEPTH"] = "2"
+		env["TAP_INCIDENT_INCIDENT_MODES"] = "standard, retrospective"
+		env["TAP_INCIDENT_STREAMS"] = `{"users": {"max_pages": 3}}`
+
+		cfg, err := config.Load("", environ())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.APIKey).To(Equal("12345"))
+		Expect(cfg.FlatteningEnabled).To(BeTrue())
+		Expect(cfg.FlatteningMaxDepth).To(Equal(2))
+		Expect(cfg.IncidentModes).To(Equal([]string{"standard", "retrospective"}))
+		Expect(cfg.Streams).To(Equal(map[string]config.StreamConfig{"users": {MaxPages: 3}}))
+	})
+
+	It("merges nested TAP_INCIDENT_ variables into the file", func() {
+		env["TAP_INCIDENT_STREAMS__INCIDENTS__PAGE_SIZE"] = "50"
+		env["TAP_INCIDENT_STREAMS__INCIDENT_UPDATES__TIMEOUT"] = "1m"
+		env["TAP_INCIDENT_BATCH_CONFIG__STORAGE__ROOT"] = "/tmp/batches"
+		env["TAP_INCIDENT_STREAMS__INCIDENTS__UNKNOWN"] = "ignored"
+		env["TAP_INCIDENT__SELECT"] = `["incidents.*"]`
+		path := write("config.yaml", "streams:\n  incidents:\n    max_pages: 2\n  users:\n    page_size: 25\n")
+
+		cfg, err := config.Load(path, environ())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Streams).To(Equal(map[string]config.StreamConfig{
+			"incidents":        {PageSize: 50, MaxPages: 2},
+			"incident_updates": {Timeout: config.Duration(time.Minute)},
+			"users":            {PageSize: 25},
+		}))
+		Expect(cfg.BatchConfig).NotTo(BeNil())
+		Expect(cfg.BatchConfig.Storage.Root).To(Equal("/tmp/batches"))
+	})
+
+	It("applies nested TAP_INCIDENT_ variables after the setting they're within", func() {
+		env["TAP_INCIDENT_STREAMS"] = `{"users": {"max_pages": 3}}`
+		env["TAP_INCIDENT_STREAMS__USERS__PAGE_SIZE"] = "10"
+
+		cfg, err := config.Load("", environ())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Streams).To(Equal(map[string]config.StreamConfig{"users": {PageSize: 10, MaxPages: 3}}))
+	})
+
+	It("returns an error for TAP_INCIDENT_ variables that don't parse", func() {
+		env["TAP_INCIDENT_FLATTENING_MAX_DEPTH"] = "two"
+
+		_, err := config.Load("", environ())
+		Expect(err).To(MatchError(ContainSubstring("parsing TAP_INCIDENT_FLATTENING_MAX_DEPTH")))
+	})
+})
@@ -0,0 +1,9 @@
+package config
+
+var (
+	// LogFormats are the formats we can log in, where logfmt is the default.
+	LogFormats = []string{"logfmt", "json"}
+	// LogLevels are the levels we can log at, from the most to the least verbose, where
+	// info is the default.
+	LogLevels = []string{"debug", "info", "warn", "error"}
+)
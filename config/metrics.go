@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// TimestampDuration is a duration the incident_metrics stream computes for each
+// incident, between two of its timestamps.
+type TimestampDuration struct {
+	// Name identifies the duration in each record, e.g. "time_to_resolve".
+	Name string `json:"name"`
+	// From and To are the names or IDs of incident timestamps, e.g. "Reported at".
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DefaultTimestampDurations use the timestamps every incident.io account has.
+var DefaultTimestampDurations = []TimestampDuration{
+	{Name: "time_to_acknowledge", From: "Reported at", To: "Accepted at"},
+	{Name: "time_to_resolve", From: "Reported at", To: "Resolved at"},
+	{Name: "time_to_close", From: "Reported at", To: "Closed at"},
+}
+
+func (d TimestampDuration) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Name, validation.Required),
+		validation.Field(&d.From, validation.Required),
+		validation.Field(&d.To, validation.Required),
+	)
+}
+
+// TimestampDurations returns the durations to compute, defaulting to
+// DefaultTimestampDurations.
+func (c StreamConfig) TimestampDurations() []TimestampDuration {
+	if len(c.Durations) == 0 {
+		return DefaultTimestampDurations
+	}
+
+	return c.Durations
+}
+
+func uniqueDurationNames(value any) error {
+	seen := map[string]bool{}
+	for _, duration := range value.([]TimestampDuration) {
+		if seen[duration.Name] {
+			return fmt.Errorf("%s is configured more than once", duration.Name)
+		}
+		seen[duration.Name] = true
+	}
+
+	return nil
+}
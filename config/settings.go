@@ -0,0 +1,195 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// SettingSchema is the JSON schema of a setting, as published for runners such as
+// Meltano to know what the tap can be configured with.
+type SettingSchema struct {
+	Type                 []string                  `json:"type"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty"`
+	Default              any                       `json:"default,omitempty"`
+	Minimum              *int                      `json:"minimum,omitempty"`
+	Properties           map[string]*SettingSchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *SettingSchema            `json:"items,omitempty"`
+	AdditionalProperties *SettingSchema            `json:"additionalProperties,omitempty"`
+	// Secret settings, such as the API key, should be stored somewhere safe and never
+	// shown, which is how the Singer SDK marks them.
+	Secret    bool `json:"secret,omitempty"`
+	WriteOnly bool `json:"writeOnly,omitempty"`
+}
+
+// Settings returns the JSON schema of the config. It's generated from the fields of
+// Config so it can't drift, adding descriptions and the constraints Validate enforces
+// from settingDetails.
+func Settings() *SettingSchema {
+	return settingSchema("", reflect.TypeOf(Config{}))
+}
+
+// settingDetail is what we can't learn by reflecting over the config, keyed by path in
+// settingDetails using "." between properties, "[]" for the items of an array and "*"
+// for the values of a map.
+type settingDetail struct {
+	Description string
+	Required    bool
+	Secret      bool
+	Enum        []string
+	Default     any
+	Minimum     *int
+}
+
+var settingDetails = map[string]settingDetail{
+	"api_key": {
+		Description: "API key for the incident.io API, which needs permission to view the data you extract.",
+		Required:    true,
+		Secret:      true,
+	},
+	"endpoint": {
+		Description: "Base URL of the incident.io API.",
+		Default:     "https://api.incident.io",
+	},
+	"log_format": {
+		Description: "How logs are written to STDERR.",
+		Enum:        LogFormats,
+		Default:     "logfmt",
+	},
+	"log_level": {
+		Description: "Least severe level that's logged.",
+		Enum:        LogLevels,
+		Default:     "info",
+	},
+	"incident_modes": {
+		Description: "Modes of incident to extract, for the incidents, follow_ups and incident_updates streams.",
+		Default:     DefaultIncidentModes,
+	},
+	"incident_modes[]": {
+		Enum: IncidentModes,
+	},
+	"streams": {
+		Description: "Settings for each stream, keyed by stream name.",
+	},
+	"streams.*.page_size": {
+		Description: "Records to request per page, defaulting to the most the endpoint allows.",
+		Minimum:     lo.ToPtr(1),
+	},
+	"streams.*.timeout": {
+		Description: "How long each request may take, e.g. \"30s\".",
+	},
+	"streams.*.max_pages": {
+		Description: "Stop after loading this many pages, except for full-table streams which load every page.",
+	},
+	"streams.*.filters": {
+		Description: "Filters applied by the API, for the incidents stream.",
+	},
+	"streams.*.soft_delete": {
+		Description: "Mark deleted records with _sdc_deleted_at rather than removing them, for full-table streams.",
+	},
+	"streams.*.durations": {
+		Description: "Durations between incident timestamps, for the incident_metrics stream.",
+	},
+	"flattening_enabled": {
+		Description: "Unnest nested objects into top-level properties, such as severity__name.",
+	},
+	"flattening_max_depth": {
+		Description: "How many levels of nesting to flatten, unlimited if unset.",
+		Minimum:     lo.ToPtr(1),
+	},
+	"transforms": {
+		Description: "Drop, null, hash or mask fields matching a path, applied in order.",
+	},
+	"transforms[].action": {
+		Enum: lo.Map(TransformActions, func(action TransformAction, _ int) string { return string(action) }),
+	},
+	"transform_presets": {
+		Description: "Presets adding transforms for common needs.",
+	},
+	"transform_presets[]": {
+		Enum: TransformPresets,
+	},
+	"hash_key": {
+		Description: "Secret used to hash fields, needed if any transform hashes them.",
+		Secret:      true,
+	},
+	"batch_config": {
+		Description: "Write records to files referenced by BATCH messages, rather than as RECORD messages. Full-table streams with table versions are always output as RECORD messages.",
+	},
+	"batch_config.encoding.format": {
+		Enum: []string{BatchFormatJSONL},
+	},
+	"batch_config.encoding.compression": {
+		Enum: []string{BatchCompressionGzip, BatchCompressionNone},
+	},
+	"batch_config.batch_size": {
+		Default: DefaultBatchSize,
+		Minimum: lo.ToPtr(1),
+	},
+}
+
+var durationType = reflect.TypeOf(Duration(0))
+
+func settingSchema(path string, typ reflect.Type) *SettingSchema {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	schema := &SettingSchema{}
+	switch {
+	case typ == durationType:
+		schema.Type = []string{"string"}
+	case typ.Kind() == reflect.String:
+		schema.Type = []string{"string"}
+	case typ.Kind() == reflect.Bool:
+		schema.Type = []string{"boolean"}
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Uint64:
+		schema.Type = []string{"integer"}
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		schema.Type = []string{"number"}
+	case typ.Kind() == reflect.Slice:
+		schema.Type = []string{"array"}
+		schema.Items = settingSchema(path+"[]", typ.Elem())
+	case typ.Kind() == reflect.Map:
+		schema.Type = []string{"object"}
+		schema.AdditionalProperties = settingSchema(joinSettingPath(path, "*"), typ.Elem())
+	case typ.Kind() == reflect.Struct:
+		schema.Type = []string{"object"}
+		schema.Properties = map[string]*SettingSchema{}
+		for idx := 0; idx < typ.NumField(); idx++ {
+			field := typ.Field(idx)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "" || name == "-" {
+				continue
+			}
+
+			fieldPath := joinSettingPath(path, name)
+			schema.Properties[name] = settingSchema(fieldPath, field.Type)
+			if settingDetails[fieldPath].Required {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	detail := settingDetails[path]
+	schema.Description = detail.Description
+	schema.Default = detail.Default
+	schema.Minimum = detail.Minimum
+	schema.Secret, schema.WriteOnly = detail.Secret, detail.Secret
+	if len(detail.Enum) > 0 {
+		schema.Enum = lo.ToAnySlice(detail.Enum)
+	}
+
+	return schema
+}
+
+func joinSettingPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// MaxPageSizes are the largest page sizes accepted by each paginated list endpoint in
+// the incident.io API, keyed by the stream (or endpoint) name. Endpoints missing from
+// this map are not paginated.
+var MaxPageSizes = map[string]int64{
+	"alerts":               50,
+	"custom_field_options": 250,
+	"escalations":          50,
+	"incident_updates":     250,
+	"incidents":            250,
+	"users":                250,
+}
+
+// FullTableStreams load a complete snapshot of their table on every sync, so a record
+// that stops appearing has been deleted. These are versioned, letting targets remove
+// deleted rows.
+var FullTableStreams = []string{
+	"alert_attributes",
+	"alert_sources",
+	"custom_field_options",
+	"custom_fields",
+	"incident_roles",
+	"incident_statuses",
+	"incident_timestamps",
+	"incident_types",
+	"severities",
+}
+
+// StreamConfig customises how a single stream is synced.
+type StreamConfig struct {
+	// PageSize is how many records to request per page, defaulting to the maximum the
+	// endpoint allows. Only applies to paginated streams.
+	PageSize int64 `json:"page_size,omitempty"`
+	// Timeout bounds each request made by the stream, e.g. "30s".
+	Timeout Duration `json:"timeout,omitempty"`
+	// MaxPages stops the stream after loading this many pages, which is useful to cap
+	// syncs while testing. Zero means no limit. Full-table streams must load every page.
+	MaxPages int `json:"max_pages,omitempty"`
+	// Filters restrict which incidents are loaded by the API. Only applies to the
+	// incidents stream.
+	Filters *IncidentFilters `json:"filters,omitempty"`
+	// SoftDelete marks records that have been deleted since the last sync with an
+	// _sdc_deleted_at timestamp, rather than having the target remove them. Only applies
+	// to full-table streams, and needs state to be passed between syncs.
+	SoftDelete bool `json:"soft_delete,omitempty"`
+	// Durations are computed for each incident, between two of its timestamps. Only
+	// applies to the incident_metrics stream, defaulting to DefaultTimestampDurations.
+	Durations []TimestampDuration `json:"durations,omitempty"`
+}
+
+// Stream returns the config for the named stream, which is empty if none was provided.
+func (c Config) Stream(name string) StreamConfig {
+	return c.Streams[name]
+}
+
+func (c StreamConfig) validate(name string) error {
+	maxPageSize, paginated := MaxPageSizes[name]
+
+	pageSizeRules := []validation.Rule{validation.Min(int64(1))}
+	if paginated {
+		pageSizeRules = append(pageSizeRules, validation.Max(maxPageSize).
+			Error(fmt.Sprintf("must be no greater than %d, the maximum the API allows", maxPageSize)))
+	} else {
+		pageSizeRules = append(pageSizeRules, validation.By(func(value any) error {
+			if value.(int64) != 0 {
+				return errors.New("cannot be set as this stream is not paginated")
+			}
+
+			return nil
+		}))
+	}
+
+	filtersRules := []validation.Rule{}
+	if name != "incidents" {
+		filtersRules = append(filtersRules, validation.By(func(value any) error {
+			if value.(*IncidentFilters) != nil {
+				return errors.New("can only be set for the incidents stream")
+			}
+
+			return nil
+		}))
+	}
+
+	softDeleteRules := []validation.Rule{}
+	if !lo.Contains(FullTableStreams, name) {
+		softDeleteRules = append(softDeleteRules, validation.By(func(value any) error {
+			if value.(bool) {
+				return errors.New("can only be set for full-table streams")
+			}
+
+			return nil
+		}))
+	}
+
+	// Full-table streams treat records they didn't load as deleted, so must load them all
+	maxPagesRules := []validation.Rule{validation.Min(0)}
+	if lo.Contains(FullTableStreams, name) {
+		maxPagesRules = append(maxPagesRules, validation.By(func(value any) error {
+			if value.(int) != 0 {
+				return errors.New("cannot be set for full-table streams, as records that aren't loaded would be treated as deleted")
+			}
+
+			return nil
+		}))
+	}
+
+	durationsRules := []validation.Rule{validation.By(uniqueDurationNames)}
+	if name != "incident_metrics" {
+		durationsRules = append(durationsRules, validation.By(func(value any) error {
+			if len(value.([]TimestampDuration)) > 0 {
+				return errors.New("can only be set for the incident_metrics stream")
+			}
+
+			return nil
+		}))
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.PageSize, pageSizeRules...),
+		validation.Field(&c.Timeout, validation.Min(Duration(0))),
+		validation.Field(&c.MaxPages, maxPagesRules...),
+		validation.Field(&c.Filters, filtersRules...),
+		validation.Field(&c.SoftDelete, softDeleteRules...),
+		validation.Field(&c.Durations, durationsRules...),
+	)
+}
+
+// validateStreams validates the config of each stream, keying any errors by the stream
+// name.
+func validateStreams(value any) error {
+	errs := validation.Errors{}
+	for name, stream := range value.(map[string]StreamConfig) {
+		if err := stream.validate(name); err != nil {
+			errs[name] = err
+		}
+	}
+
+	return errs.Filter()
+}
+
+// Duration is a time.Duration that is configured as a string, e.g. "1m30s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrap(err, "durations must be strings, e.g. \"30s\"")
+	}
+
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// TransformAction is what a transform does to the fields it matches.
+type TransformAction string
+
+var (
+	// TransformDrop removes the field from the schema and every record.
+	TransformDrop TransformAction = "drop"
+	// TransformNull keeps the field, but always as null.
+	TransformNull TransformAction = "null"
+	// TransformHash replaces the value with a keyed HMAC-SHA256, so it can still be
+	// joined on but not reversed.
+	TransformHash TransformAction = "hash"
+	// TransformMask keeps the first character, replacing the rest with asterisks.
+	TransformMask TransformAction = "mask"
+
+	TransformActions = []TransformAction{TransformDrop, TransformNull, TransformHash, TransformMask}
+)
+
+// TransformPresetPII hashes the email and Slack user ID, and masks the name, of every
+// user in every stream: any object with an email property.
+const TransformPresetPII = "pii"
+
+// TransformPresets are the presets we know about.
+var TransformPresets = []string{TransformPresetPII}
+
+// Transform changes the value of fields after records are serialized, such as to keep
+// personal data out of the warehouse.
+type Transform struct {
+	// Path to the fields, using "." between properties and "[]" for the items of an
+	// array, e.g. "creator.user.email" or "custom_roles[].name". A "*" matches any one
+	// property, and "**" any number of them, so "**.email" matches every email.
+	Path   string          `json:"path"`
+	Action TransformAction `json:"action"`
+	// Streams restricts the transform to these streams. Applies to every stream if empty.
+	Streams []string `json:"streams,omitempty"`
+}
+
+func (t Transform) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Path, validation.Required),
+		validation.Field(&t.Action, validation.Required, validation.In(lo.ToAnySlice(TransformActions)...)),
+	)
+}
+
+// AppliesTo returns whether the transform should be applied to the stream.
+func (t Transform) AppliesTo(stream string) bool {
+	return len(t.Streams) == 0 || lo.Contains(t.Streams, stream)
+}
+
+// HashesFields returns whether any transform hashes values, which requires a key.
+func (c Config) HashesFields() bool {
+	return len(c.TransformPresets) > 0 || lo.ContainsBy(c.Transforms, func(t Transform) bool {
+		return t.Action == TransformHash
+	})
+}
+
+func (c Config) validateHashKey(value any) error {
+	if c.HashesFields() && value.(string) == "" {
+		return errors.New("must be set to hash fields")
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package fakeapi
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/incident-io/singer-tap/client"
+	"github.com/samber/lo"
+)
+
+// Data is everything the fake API can serve. Each slice is served in order, so keep
+// entries sorted by ID if you build this by hand.
+type Data struct {
+	Incidents           []client.IncidentV2
+	IncidentUpdates     []client.IncidentUpdateV2
+	IncidentAttachments []client.IncidentAttachmentV1
+	FollowUps           []client.FollowUpV2
+	Alerts              []client.AlertV2
+	Escalations         []client.EscalationV2
+	Users               []client.UserWithRolesV2
+	CustomFields        []client.CustomFieldV2
+	CustomFieldOptions  []client.CustomFieldOptionV1
+}
+
+// Size controls how much synthetic data Generate produces.
+type Size struct {
+	Incidents              int
+	UpdatesPerIncident     int
+	AttachmentsPerIncident int
+	FollowUpsPerIncident   int
+	Alerts                 int
+	Escalations            int
+	Users                  int
+	CustomFields           int
+	OptionsPerCustomField  int
+}
+
+// Generate builds a synthetic dataset of the given size. The same seed always produces
+// the same data, so tests can make assertions about it.
+func Generate(seed int64, size Size) *Data {
+	g := &generator{
+		rand: rand.New(rand.NewSource(seed)),
+		now:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ids:  map[string]int{},
+	}
+
+	data := &Data{}
+	for i := 0; i < size.Users; i++ {
+		data.Users = append(data.Users, g.user())
+	}
+	for i := 0; i < size.CustomFields; i++ {
+		field := g.customField()
+		data.CustomFields = append(data.CustomFields, field)
+		for i := 0; i < size.OptionsPerCustomField; i++ {
+			data.CustomFieldOptions = append(data.CustomFieldOptions, g.customFieldOption(field))
+		}
+	}
+	for i := 0; i < size.Incidents; i++ {
+		incident := g.incident()
+		data.Incidents = append(data.Incidents, incident)
+		for i := 0; i < size.UpdatesPerIncident; i++ {
+			data.IncidentUpdates = append(data.IncidentUpdates, g.incidentUpdate(incident))
+		}
+		for i := 0; i < size.AttachmentsPerIncident; i++ {
+			data.IncidentAttachments = append(data.IncidentAttachments, g.incidentAttachment(incident))
+		}
+		for i := 0; i < size.FollowUpsPerIncident; i++ {
+			data.FollowUps = append(data.FollowUps, g.followUp(incident))
+		}
+	}
+	for i := 0; i < size.Alerts; i++ {
+		data.Alerts = append(data.Alerts, g.alert())
+	}
+	for i := 0; i < size.Escalations; i++ {
+		data.Escalations = append(data.Escalations, g.escalation())
+	}
+
+	return data
+}
+
+type generator struct {
+	rand *rand.Rand
+	now  time.Time
+	ids  map[string]int
+}
+
+// id returns a unique, ULID-shaped identifier that sorts in generation order.
+func (g *generator) id(prefix string) string {
+	g.ids[prefix]++
+	return fmt.Sprintf("01%s%0*d", prefix, 24-len(prefix), g.ids[prefix])
+}
+
+func (g *generator) timestamp() time.Time {
+	return g.now.Add(-time.Duration(g.rand.Intn(365*24)) * time.Hour)
+}
+
+func (g *generator) user() client.UserWithRolesV2 {
+	id := g.id("USR")
+	return client.UserWithRolesV2{
+		Id:          id,
+		Name:        fmt.Sprintf("User %s", id[len(id)-4:]),
+		Email:       lo.ToPtr(fmt.Sprintf("user-%s@example.com", id[len(id)-4:])),
+		SlackUserId: lo.ToPtr(fmt.Sprintf("U%s", id[len(id)-8:])),
+		Role:        client.UserWithRolesV2RoleResponder,
+		BaseRole:    client.RBACRoleV2{Id: "01RBACRESPONDER", Name: "Responder", Slug: "responder"},
+		CustomRoles: []client.RBACRoleV2{},
+	}
+}
+
+func (g *generator) actor() client.ActorV2 {
+	id := g.id("ACT")
+	return client.ActorV2{
+		User: &client.UserV2{
+			Id:    id,
+			Name:  fmt.Sprintf("Actor %s", id[len(id)-4:]),
+			Email: lo.ToPtr(fmt.Sprintf("actor-%s@example.com", id[len(id)-4:])),
+			Role:  client.UserV2RoleResponder,
+		},
+	}
+}
+
+func (g *generator) customField() client.CustomFieldV2 {
+	id := g.id("CF")
+	return client.CustomFieldV2{
+		Id:          id,
+		Name:        fmt.Sprintf("Custom field %s", id[len(id)-4:]),
+		Description: "A generated custom field",
+		FieldType:   client.CustomFieldV2FieldTypeSingleSelect,
+		CreatedAt:   g.timestamp(),
+		UpdatedAt:   g.now,
+	}
+}
+
+func (g *generator) customFieldOption(field client.CustomFieldV2) client.CustomFieldOptionV1 {
+	id := g.id("CFO")
+	return client.CustomFieldOptionV1{
+		Id:            id,
+		CustomFieldId: field.Id,
+		SortKey:       int64(g.ids["CFO"]),
+		Value:         fmt.Sprintf("Option %s", id[len(id)-4:]),
+	}
+}
+
+var (
+	statusCategories = []client.IncidentStatusV2Category{
+		client.IncidentStatusV2CategoryTriage,
+		client.IncidentStatusV2CategoryLive,
+		client.IncidentStatusV2CategoryLearning,
+		client.IncidentStatusV2CategoryClosed,
+	}
+	incidentModes = []client.IncidentV2Mode{
+		client.IncidentV2ModeStandard,
+		client.IncidentV2ModeStandard,
+		client.IncidentV2ModeStandard,
+		client.IncidentV2ModeRetrospective,
+		client.IncidentV2ModeTest,
+		client.IncidentV2ModeTutorial,
+	}
+)
+
+func (g *generator) incidentStatus() client.IncidentStatusV2 {
+	rank := g.rand.Intn(len(statusCategories))
+	return client.IncidentStatusV2{
+		Id:          fmt.Sprintf("01STATUS%018d", rank),
+		Name:        string(statusCategories[rank]),
+		Description: "A generated incident status",
+		Category:    statusCategories[rank],
+		Rank:        int64(rank),
+		CreatedAt:   g.now,
+		UpdatedAt:   g.now,
+	}
+}
+
+func (g *generator) severity() *client.SeverityV2 {
+	rank := g.rand.Intn(3)
+	return &client.SeverityV2{
+		Id:          fmt.Sprintf("01SEVERITY%016d", rank),
+		Name:        fmt.Sprintf("Sev %d", rank+1),
+		Description: "A generated severity",
+		Rank:        int64(rank),
+		CreatedAt:   g.now,
+		UpdatedAt:   g.now,
+	}
+}
+
+func (g *generator) incident() client.IncidentV2 {
+	id := g.id("INC")
+	createdAt := g.timestamp()
+
+	return client.IncidentV2{
+		Id:                      id,
+		Name:                    fmt.Sprintf("Incident %s", id[len(id)-4:]),
+		Reference:               fmt.Sprintf("INC-%d", g.ids["INC"]),
+		Mode:                    incidentModes[g.rand.Intn(len(incidentModes))],
+		Visibility:              client.IncidentV2VisibilityPublic,
+		Creator:                 g.actor(),
+		CustomFieldEntries:      []client.CustomFieldEntryV2{},
+		IncidentRoleAssignments: []client.IncidentRoleAssignmentV2{},
+		IncidentStatus:          g.incidentStatus(),
+		IncidentTimestampValues: &[]client.IncidentTimestampWithValueV2{
+			{
+				IncidentTimestamp: client.IncidentTimestampV2{Id: "01TIMESTAMPREPORTED", Name: "Reported at", Rank: 1},
+				Value:             &client.IncidentTimestampValueV2{Value: &createdAt},
+			},
+		},
+		Severity:       g.severity(),
+		SlackChannelId: fmt.Sprintf("C%s", id[len(id)-8:]),
+		SlackTeamId:    "T0000000001",
+		Summary:        lo.ToPtr("A generated incident"),
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt.Add(time.Hour),
+	}
+}
+
+func (g *generator) incidentUpdate(incident client.IncidentV2) client.IncidentUpdateV2 {
+	return client.IncidentUpdateV2{
+		Id:                g.id("UPD"),
+		IncidentId:        incident.Id,
+		Message:           lo.ToPtr("A generated update"),
+		NewIncidentStatus: g.incidentStatus(),
+		NewSeverity:       g.severity(),
+		Updater:           g.actor(),
+		CreatedAt:         incident.CreatedAt.Add(time.Duration(g.rand.Intn(60)) * time.Minute),
+	}
+}
+
+func (g *generator) incidentAttachment(incident client.IncidentV2) client.IncidentAttachmentV1 {
+	id := g.id("ATT")
+	return client.IncidentAttachmentV1{
+		Id:         id,
+		IncidentId: incident.Id,
+		Resource: client.ExternalResourceV1{
+			ExternalId:   id,
+			Permalink:    fmt.Sprintf("https://example.com/%s", id),
+			ResourceType: client.ExternalResourceV1ResourceTypeGithubPullRequest,
+			Title:        fmt.Sprintf("Attachment %s", id[len(id)-4:]),
+		},
+	}
+}
+
+func (g *generator) followUp(incident client.IncidentV2) client.FollowUpV2 {
+	id := g.id("FUP")
+	return client.FollowUpV2{
+		Id:         id,
+		IncidentId: incident.Id,
+		Status:     client.Outstanding,
+		Title:      fmt.Sprintf("Follow-up %s", id[len(id)-4:]),
+		CreatedAt:  incident.CreatedAt.Add(time.Hour),
+		UpdatedAt:  incident.CreatedAt.Add(time.Hour),
+	}
+}
+
+func (g *generator) alert() client.AlertV2 {
+	id := g.id("ALR")
+	createdAt := g.timestamp()
+
+	status := client.AlertV2StatusFiring
+	var resolvedAt *time.Time
+	if g.rand.Intn(2) == 0 {
+		status, resolvedAt = client.AlertV2StatusResolved, lo.ToPtr(createdAt.Add(time.Hour))
+	}
+
+	return client.AlertV2{
+		Id:               id,
+		AlertSourceId:    "01ALERTSOURCE",
+		Attributes:       []client.AlertAttributeEntryV2{},
+		DeduplicationKey: id,
+		Status:           status,
+		Title:            fmt.Sprintf("Alert %s", id[len(id)-4:]),
+		ResolvedAt:       resolvedAt,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	}
+}
+
+func (g *generator) escalation() client.EscalationV2 {
+	id := g.id("ESC")
+	createdAt := g.timestamp()
+
+	return client.EscalationV2{
+		Id:               id,
+		Title:            fmt.Sprintf("Escalation %s", id[len(id)-4:]),
+		Status:           client.Acked,
+		Creator:          client.EscalationCreatorV2{User: g.actor().User},
+		Priority:         client.EscalationPriorityV2{Name: "High"},
+		Events:           []client.EscalationEventV2{},
+		RelatedAlerts:    []client.AlertSlimV2{},
+		RelatedIncidents: []client.IncidentSlimV2{},
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	}
+}
@@ -0,0 +1,291 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/incident-io/singer-tap/client"
+	"github.com/samber/lo"
+)
+
+// defaultIncidentModes are the modes returned by the incidents API when no mode filter
+// is provided, excluding test and tutorial incidents.
+var defaultIncidentModes = []string{
+	string(client.IncidentV2ModeStandard), string(client.IncidentV2ModeRetrospective),
+}
+
+func (s *Server) listIncidents(query url.Values) (*listing, error) {
+	if _, ok := operators(query, "mode"); !ok {
+		query.Set("mode[one_of]", strings.Join(defaultIncidentModes, ","))
+	}
+
+	incidents := []client.IncidentV2{}
+	for _, incident := range s.data.Incidents {
+		var typeID string
+		if incident.IncidentType != nil {
+			typeID = incident.IncidentType.Id
+		}
+		var severityID string
+		if incident.Severity != nil {
+			severityID = incident.Severity.Id
+		}
+
+		ok, err := matchesAll(query, map[string]string{
+			"status":          incident.IncidentStatus.Id,
+			"status_category": string(incident.IncidentStatus.Category),
+			"severity":        severityID,
+			"incident_type":   typeID,
+			"mode":            string(incident.Mode),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			incidents = append(incidents, incident)
+		}
+	}
+
+	return &listing{
+		ids: lo.Map(incidents, func(incident client.IncidentV2, _ int) string { return incident.Id }),
+		render: func(from, to int, meta *client.PaginationMetaResultV2) any {
+			total := int64(len(incidents))
+			return client.IncidentsListResultV2{
+				Incidents: incidents[from:to],
+				PaginationMeta: &client.PaginationMetaResultWithTotalV2{
+					After:            meta.After,
+					PageSize:         meta.PageSize,
+					TotalRecordCount: &total,
+				},
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listIncidentUpdates(query url.Values) (*listing, error) {
+	updates := lo.Filter(s.data.IncidentUpdates, func(update client.IncidentUpdateV2, _ int) bool {
+		return query.Get("incident_id") == "" || update.IncidentId == query.Get("incident_id")
+	})
+
+	return &listing{
+		ids: lo.Map(updates, func(update client.IncidentUpdateV2, _ int) string { return update.Id }),
+		render: func(from, to int, meta *client.PaginationMetaResultV2) any {
+			return client.IncidentUpdatesListResultV2{
+				IncidentUpdates: updates[from:to],
+				PaginationMeta:  meta,
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listIncidentAttachments(query url.Values) (*listing, error) {
+	if query.Get("incident_id") == "" && query.Get("external_id") == "" {
+		return nil, fmt.Errorf("one of incident_id or external_id must be provided")
+	}
+
+	attachments := lo.Filter(s.data.IncidentAttachments, func(attachment client.IncidentAttachmentV1, _ int) bool {
+		return query.Get("incident_id") == "" || attachment.IncidentId == query.Get("incident_id")
+	})
+
+	return &listing{
+		ids: lo.Map(attachments, func(attachment client.IncidentAttachmentV1, _ int) string { return attachment.Id }),
+		render: func(from, to int, _ *client.PaginationMetaResultV2) any {
+			return client.IncidentAttachmentsListResultV1{
+				IncidentAttachments: attachments[from:to],
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listFollowUps(query url.Values) (*listing, error) {
+	modes := defaultIncidentModes
+	if mode := query.Get("incident_mode"); mode != "" {
+		modes = []string{mode}
+	}
+
+	incidentModes := map[string]string{}
+	for _, incident := range s.data.Incidents {
+		incidentModes[incident.Id] = string(incident.Mode)
+	}
+
+	followUps := lo.Filter(s.data.FollowUps, func(followUp client.FollowUpV2, _ int) bool {
+		if incidentID := query.Get("incident_id"); incidentID != "" && followUp.IncidentId != incidentID {
+			return false
+		}
+
+		return lo.Contains(modes, incidentModes[followUp.IncidentId])
+	})
+
+	return &listing{
+		ids: lo.Map(followUps, func(followUp client.FollowUpV2, _ int) string { return followUp.Id }),
+		render: func(from, to int, _ *client.PaginationMetaResultV2) any {
+			return client.FollowUpsListResultV2{
+				FollowUps: followUps[from:to],
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listAlerts(query url.Values) (*listing, error) {
+	alerts := []client.AlertV2{}
+	for _, alert := range s.data.Alerts {
+		ok, err := matches(query, "status", string(alert.Status))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return &listing{
+		ids: lo.Map(alerts, func(alert client.AlertV2, _ int) string { return alert.Id }),
+		render: func(from, to int, meta *client.PaginationMetaResultV2) any {
+			return client.AlertsListResultV2{
+				Alerts:         alerts[from:to],
+				PaginationMeta: *meta,
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listEscalations(query url.Values) (*listing, error) {
+	escalations := []client.EscalationV2{}
+	for _, escalation := range s.data.Escalations {
+		ok, err := matches(query, "status", string(escalation.Status))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			escalations = append(escalations, escalation)
+		}
+	}
+
+	return &listing{
+		ids: lo.Map(escalations, func(escalation client.EscalationV2, _ int) string { return escalation.Id }),
+		render: func(from, to int, meta *client.PaginationMetaResultV2) any {
+			return client.EscalationsListResultV2{
+				Escalations:    escalations[from:to],
+				PaginationMeta: *meta,
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listUsers(query url.Values) (*listing, error) {
+	users := lo.Filter(s.data.Users, func(user client.UserWithRolesV2, _ int) bool {
+		if email := query.Get("email"); email != "" && lo.FromPtr(user.Email) != email {
+			return false
+		}
+		if slackUserID := query.Get("slack_user_id"); slackUserID != "" && lo.FromPtr(user.SlackUserId) != slackUserID {
+			return false
+		}
+
+		return true
+	})
+
+	return &listing{
+		ids: lo.Map(users, func(user client.UserWithRolesV2, _ int) string { return user.Id }),
+		render: func(from, to int, meta *client.PaginationMetaResultV2) any {
+			return client.UsersListResultV2{
+				Users:          users[from:to],
+				PaginationMeta: *meta,
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listCustomFields(query url.Values) (*listing, error) {
+	return &listing{
+		ids: lo.Map(s.data.CustomFields, func(field client.CustomFieldV2, _ int) string { return field.Id }),
+		render: func(from, to int, _ *client.PaginationMetaResultV2) any {
+			return client.CustomFieldsListResultV2{
+				CustomFields: s.data.CustomFields[from:to],
+			}
+		},
+	}, nil
+}
+
+func (s *Server) listCustomFieldOptions(query url.Values) (*listing, error) {
+	customFieldID := query.Get("custom_field_id")
+	if customFieldID == "" {
+		return nil, fmt.Errorf("custom_field_id is required")
+	}
+
+	options := lo.Filter(s.data.CustomFieldOptions, func(option client.CustomFieldOptionV1, _ int) bool {
+		return option.CustomFieldId == customFieldID
+	})
+
+	return &listing{
+		ids: lo.Map(options, func(option client.CustomFieldOptionV1, _ int) string { return option.Id }),
+		render: func(from, to int, meta *client.PaginationMetaResultV2) any {
+			return client.CustomFieldOptionsListResultV1{
+				CustomFieldOptions: options[from:to],
+				PaginationMeta: client.PaginationMetaResultV1{
+					After:    meta.After,
+					PageSize: meta.PageSize,
+				},
+			}
+		},
+	}, nil
+}
+
+// operators returns the filter operators provided for a parameter, which the API
+// accepts in the form `param[operator]=value`, with comma separated values.
+func operators(query url.Values, param string) (map[string][]string, bool) {
+	result := map[string][]string{}
+	for key, values := range query {
+		operator, ok := strings.CutPrefix(key, param+"[")
+		if !ok {
+			continue
+		}
+		operator, ok = strings.CutSuffix(operator, "]")
+		if !ok {
+			continue
+		}
+
+		for _, value := range values {
+			result[operator] = append(result[operator], strings.Split(value, ",")...)
+		}
+	}
+
+	return result, len(result) > 0
+}
+
+// matchesAll applies the filters for each parameter to the corresponding value.
+func matchesAll(query url.Values, values map[string]string) (bool, error) {
+	for param, value := range values {
+		ok, err := matches(query, param, value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matches applies any filters for the given parameter to a value, erroring if the
+// filter uses an operator we don't support.
+func matches(query url.Values, param, value string) (bool, error) {
+	filters, ok := operators(query, param)
+	if !ok {
+		return true, nil
+	}
+
+	for operator, values := range filters {
+		switch operator {
+		case "one_of":
+			if !lo.Contains(values, value) {
+				return false, nil
+			}
+		case "not_in":
+			if lo.Contains(values, value) {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported operator for %s: %s", param, operator)
+		}
+	}
+
+	return true, nil
+}
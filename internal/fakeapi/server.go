@@ -0,0 +1,279 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/incident-io/singer-tap/client"
+	"github.com/samber/lo"
+)
+
+// Server is an in-memory fake of the incident.io API, implementing the list endpoints
+// that the tap streams consume.
+//
+// It aims to behave like the real API where it matters to the tap: results are ordered
+// by ID, paginated using `after` and `page_size` with the same per-endpoint limits, and
+// pagination_meta only includes an `after` cursor when there are more results to load.
+//
+// Use it with httptest.NewServer and point client.New at the resulting URL.
+type Server struct {
+	data *Data
+
+	mu        sync.Mutex
+	requests  []Request
+	faults    []*injectedFault
+	rateLimit *RateLimit
+	window    time.Time
+	served    int
+}
+
+// Request is a record of a request the server received, used by tests to assert on how
+// many calls were made and with what parameters.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+// Fault is an error the server returns instead of serving a request.
+type Fault struct {
+	// Path is the endpoint to fail, e.g. "/v2/alerts". If empty, every endpoint fails.
+	Path string
+	// Status is the HTTP status code to respond with.
+	Status int
+	// Message is the error message in the response body, defaulting to the status text.
+	Message string
+	// Skip is how many matching requests to serve successfully before failing.
+	Skip int
+	// Times is how many matching requests to fail, or zero to fail forever.
+	Times int
+}
+
+// injectedFault tracks how many requests a fault has seen.
+type injectedFault struct {
+	Fault
+	seen int
+}
+
+// RateLimit makes the server respond with 429s once more than Requests requests have
+// been served inside a single Window.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithRateLimit applies a rate limit to every endpoint.
+func WithRateLimit(limit RateLimit) Option {
+	return func(s *Server) {
+		s.rateLimit = &limit
+	}
+}
+
+// WithFault injects an error into the server's responses.
+func WithFault(fault Fault) Option {
+	return func(s *Server) {
+		s.faults = append(s.faults, &injectedFault{Fault: fault})
+	}
+}
+
+// New builds a server that serves the given data.
+func New(data *Data, opts ...Option) *Server {
+	s := &Server{data: data}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Inject adds a fault to a running server.
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &injectedFault{Fault: fault})
+}
+
+// Requests returns every request received for the given path, or all requests if the
+// path is empty.
+func (s *Server) Requests(path string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return lo.Filter(s.requests, func(req Request, _ int) bool {
+		return path == "" || req.Path == path
+	})
+}
+
+// endpoint describes how to serve a single list endpoint.
+type endpoint struct {
+	// defaultPageSize and maxPageSize mirror the real API, where zero means the endpoint
+	// is not paginated.
+	defaultPageSize int64
+	maxPageSize     int64
+	// requirePageSize is set for endpoints whose page_size parameter is mandatory.
+	requirePageSize bool
+	// list returns the IDs of every matching result, in order, and a function to build
+	// the response body for a page of those results.
+	list func(query url.Values) (*listing, error)
+}
+
+// listing is the filtered set of results for a list request.
+type listing struct {
+	ids    []string
+	render func(from, to int, meta *client.PaginationMetaResultV2) any
+}
+
+func (s *Server) endpoints() map[string]endpoint {
+	return map[string]endpoint{
+		"/v2/incidents":            {defaultPageSize: 25, maxPageSize: 250, list: s.listIncidents},
+		"/v2/incident_updates":     {defaultPageSize: 25, maxPageSize: 250, list: s.listIncidentUpdates},
+		"/v1/incident_attachments": {list: s.listIncidentAttachments},
+		"/v2/follow_ups":           {list: s.listFollowUps},
+		"/v2/alerts":               {maxPageSize: 50, requirePageSize: true, list: s.listAlerts},
+		"/v2/escalations":          {defaultPageSize: 25, maxPageSize: 50, list: s.listEscalations},
+		"/v2/users":                {defaultPageSize: 25, maxPageSize: 250, list: s.listUsers},
+		"/v2/custom_fields":        {list: s.listCustomFields},
+		"/v1/custom_field_options": {defaultPageSize: 25, maxPageSize: 250, list: s.listCustomFieldOptions},
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if fault, retryAfter := s.admit(r); fault != nil {
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
+
+		writeError(w, fault.Status, fault.Message)
+		return
+	}
+
+	ep, ok := s.endpoints()[r.URL.Path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "")
+		return
+	}
+
+	query := r.URL.Query()
+
+	pageSize := ep.defaultPageSize
+	if raw := query.Get("page_size"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 || (ep.maxPageSize > 0 && parsed > ep.maxPageSize) {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid page_size: %s", raw))
+			return
+		}
+		pageSize = parsed
+	} else if ep.requirePageSize {
+		writeError(w, http.StatusUnprocessableEntity, "page_size is required")
+		return
+	}
+
+	result, err := ep.list(query)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	// Unpaginated endpoints return everything in one go, and have no pagination_meta.
+	if ep.maxPageSize == 0 {
+		writeJSON(w, result.render(0, len(result.ids), nil))
+		return
+	}
+
+	from := 0
+	if after := query.Get("after"); after != "" {
+		idx := lo.IndexOf(result.ids, after)
+		if idx < 0 {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid after: %s", after))
+			return
+		}
+		from = idx + 1
+	}
+
+	to := int(math.Min(float64(from)+float64(pageSize), float64(len(result.ids))))
+	meta := &client.PaginationMetaResultV2{PageSize: pageSize}
+	if to < len(result.ids) {
+		meta.After = lo.ToPtr(result.ids[to-1])
+	}
+
+	writeJSON(w, result.render(from, to, meta))
+}
+
+// admit records the request and decides whether it should fail, returning the fault
+// to respond with and how many seconds the client should wait before retrying.
+func (s *Server) admit(r *http.Request) (*Fault, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+	})
+
+	if s.rateLimit != nil {
+		now := time.Now()
+		if now.Sub(s.window) >= s.rateLimit.Window {
+			s.window, s.served = now, 0
+		}
+		if s.served >= s.rateLimit.Requests {
+			retryAfter := int(math.Ceil(s.window.Add(s.rateLimit.Window).Sub(now).Seconds()))
+			return &Fault{Status: http.StatusTooManyRequests, Message: "rate limit exceeded"}, retryAfter
+		}
+		s.served++
+	}
+
+	for _, fault := range s.faults {
+		if fault.Path != "" && fault.Path != r.URL.Path {
+			continue
+		}
+		fault.seen++
+		if fault.seen <= fault.Skip {
+			continue
+		}
+		if fault.Times > 0 && fault.seen > fault.Skip+fault.Times {
+			continue
+		}
+
+		return &fault.Fault, 0
+	}
+
+	return nil, 0
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"type":   "validation_error",
+		"status": status,
+		"errors": []map[string]any{
+			{"code": "invalid_value", "message": message},
+		},
+	})
+}
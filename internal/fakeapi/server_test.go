@@ -0,0 +1,133 @@
+package fakeapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		ctx    context.Context
+		data   *fakeapi.Data
+		opts   []fakeapi.Option
+		server *fakeapi.Server
+		cl     *client.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		// We're testing the responses of the server, so don't want them retried.
+		ctx = client.WithRetryPolicy(context.Background(), client.RetryPolicy{})
+		data = fakeapi.Generate(1, fakeapi.Size{Incidents: 30, Alerts: 5})
+		opts = nil
+	})
+
+	JustBeforeEach(func() {
+		server = fakeapi.New(data, opts...)
+		httpServer := httptest.NewServer(server)
+		DeferCleanup(httpServer.Close)
+
+		var err error
+		cl, err = client.New(ctx, "api-key", httpServer.URL, "test")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("pagination", func() {
+		It("only returns an after cursor when there are more results", func() {
+			first, err := cl.AlertsV2ListWithResponse(ctx, &client.AlertsV2ListParams{PageSize: 3})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.JSON200.Alerts).To(HaveLen(3))
+			Expect(first.JSON200.PaginationMeta.After).To(Equal(lo.ToPtr(first.JSON200.Alerts[2].Id)))
+
+			second, err := cl.AlertsV2ListWithResponse(ctx, &client.AlertsV2ListParams{
+				PageSize: 3, After: first.JSON200.PaginationMeta.After,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.JSON200.Alerts).To(HaveLen(2))
+			Expect(second.JSON200.PaginationMeta.After).To(BeNil())
+		})
+
+		It("rejects page sizes above the endpoint maximum", func() {
+			_, err := cl.AlertsV2ListWithResponse(ctx, &client.AlertsV2ListParams{PageSize: 51})
+			Expect(err).To(MatchError(ContainSubstring("status 422")))
+		})
+	})
+
+	Describe("filters", func() {
+		It("excludes test and tutorial incidents by default", func() {
+			response, err := cl.IncidentsV2ListWithResponse(ctx, &client.IncidentsV2ListParams{
+				PageSize: lo.ToPtr(int64(250)),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.JSON200.Incidents).NotTo(BeEmpty())
+
+			for _, incident := range response.JSON200.Incidents {
+				Expect(incident.Mode).To(BeElementOf(client.IncidentV2ModeStandard, client.IncidentV2ModeRetrospective))
+			}
+		})
+
+		It("applies operator filters", func() {
+			response, err := cl.IncidentsV2ListWithResponse(ctx, &client.IncidentsV2ListParams{
+				PageSize: lo.ToPtr(int64(250)),
+			}, func(ctx context.Context, req *http.Request) error {
+				query := req.URL.Query()
+				query.Add("mode[one_of]", "test")
+				req.URL.RawQuery = query.Encode()
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.JSON200.Incidents).NotTo(BeEmpty())
+
+			for _, incident := range response.JSON200.Incidents {
+				Expect(incident.Mode).To(Equal(client.IncidentV2ModeTest))
+			}
+		})
+	})
+
+	Describe("faults", func() {
+		BeforeEach(func() {
+			opts = append(opts, fakeapi.WithFault(fakeapi.Fault{
+				Path: "/v2/alerts", Status: http.StatusInternalServerError, Skip: 1, Times: 1,
+			}))
+		})
+
+		It("fails the configured requests only", func() {
+			params := &client.AlertsV2ListParams{PageSize: 1}
+
+			_, err := cl.AlertsV2ListWithResponse(ctx, params)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cl.AlertsV2ListWithResponse(ctx, params)
+			Expect(err).To(MatchError(ContainSubstring("status 500")))
+			_, err = cl.AlertsV2ListWithResponse(ctx, params)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(server.Requests("/v2/alerts")).To(HaveLen(3))
+		})
+	})
+
+	Describe("rate limiting", func() {
+		BeforeEach(func() {
+			opts = append(opts, fakeapi.WithRateLimit(fakeapi.RateLimit{Requests: 2, Window: time.Minute}))
+		})
+
+		It("responds with 429 once the limit is exceeded", func() {
+			params := &client.AlertsV2ListParams{PageSize: 1}
+
+			for i := 0; i < 2; i++ {
+				_, err := cl.AlertsV2ListWithResponse(ctx, params)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := cl.AlertsV2ListWithResponse(ctx, params)
+			Expect(err).To(MatchError(ContainSubstring("status 429")))
+		})
+	})
+})
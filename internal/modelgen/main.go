@@ -0,0 +1,470 @@
+// Command modelgen generates the Schema and Serialize methods in the model package from
+// the OpenAPI spec the client is generated from, so new API fields show up in the tap
+// without anyone having to hand-write them.
+//
+// It's run by go generate in the model package:
+//
+//	go run ../internal/modelgen -spec ../client/openapi3.json -overrides overrides.json -out .
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+func main() {
+	var (
+		specFile      = flag.String("spec", "client/openapi3.json", "OpenAPI spec to generate from")
+		overridesFile = flag.String("overrides", "model/overrides.json", "Overrides for the generated models")
+		outDir        = flag.String("out", "model", "Directory to write the generated files to")
+	)
+	flag.Parse()
+
+	if err := run(*specFile, *overridesFile, *outDir); err != nil {
+		fmt.Fprintf(os.Stderr, "modelgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specFile, overridesFile, outDir string) error {
+	var spec Spec
+	if err := readJSON(specFile, &spec); err != nil {
+		return errors.Wrap(err, "reading spec")
+	}
+
+	var overrides Overrides
+	if err := readJSON(overridesFile, &overrides); err != nil {
+		return errors.Wrap(err, "reading overrides")
+	}
+
+	models, err := Build(spec, overrides)
+	if err != nil {
+		return err
+	}
+
+	// Remove what we generated last time, so models that are no longer needed go away.
+	stale, err := filepath.Glob(filepath.Join(outDir, "*.gen.go"))
+	if err != nil {
+		return err
+	}
+	for _, file := range stale {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	for _, model := range models {
+		source, err := Render(model)
+		if err != nil {
+			return errors.Wrap(err, model.Name)
+		}
+
+		if err := os.WriteFile(filepath.Join(outDir, model.FileName()), source, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readJSON(path string, into any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, into)
+}
+
+// Spec is the subset of an OpenAPI 3 spec that we generate models from.
+type Spec struct {
+	Components struct {
+		Schemas map[string]Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Schema is the subset of an OpenAPI schema that appears in the models we generate.
+type Schema struct {
+	Ref         string            `json:"$ref"`
+	Type        string            `json:"type"`
+	Format      string            `json:"format"`
+	Description string            `json:"description"`
+	Nullable    bool              `json:"nullable"`
+	Properties  map[string]Schema `json:"properties"`
+	Required    []string          `json:"required"`
+	Items       *Schema           `json:"items"`
+}
+
+// RefName returns the name of the component a $ref points at.
+func (s Schema) RefName() string {
+	return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+}
+
+// Overrides customise the generated models.
+type Overrides struct {
+	// Roots are the components the streams serialize. We generate a model for each, and
+	// for every component they reference.
+	Roots []string `json:"roots"`
+	// Schemas customises the model of individual components.
+	Schemas map[string]SchemaOverride `json:"schemas"`
+}
+
+// SchemaOverride customises the model of a single component.
+type SchemaOverride struct {
+	// Exclude removes properties from the model.
+	Exclude []string `json:"exclude"`
+	// Rename changes the name of properties in the model, from the API name to the name
+	// we want in the record.
+	Rename map[string]string `json:"rename"`
+	// IncludeDeprecated keeps properties that are documented as deprecated, which we
+	// otherwise leave out.
+	IncludeDeprecated bool `json:"include_deprecated"`
+}
+
+// Model is a component we generate Schema and Serialize methods for.
+type Model struct {
+	// Name is the component name, which is also the name of the client type and the
+	// exported variable in the model package.
+	Name       string
+	Properties []Field
+	// UsesLo is set if the Serialize method maps over arrays.
+	UsesLo bool
+}
+
+// FileName is the snake_case name of the generated file, e.g. incident_v2.gen.go.
+func (m Model) FileName() string {
+	var name strings.Builder
+	runes := []rune(m.Name)
+	for idx, r := range runes {
+		if idx > 0 && unicode.IsUpper(r) {
+			prev := runes[idx-1]
+			acronymEnd := unicode.IsUpper(prev) && idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || acronymEnd {
+				name.WriteRune('_')
+			}
+		}
+		name.WriteRune(unicode.ToLower(r))
+	}
+
+	return name.String() + ".gen.go"
+}
+
+// Receiver is the unexported type the methods are declared on.
+func (m Model) Receiver() string {
+	runes := []rune(m.Name)
+	idx := 0
+	for idx < len(runes) && unicode.IsUpper(runes[idx]) {
+		idx++
+	}
+	// Lowercase a leading acronym, leaving the first letter of the next word: APIKeyV2
+	// becomes apiKeyV2.
+	if idx > 1 && idx < len(runes) {
+		idx--
+	}
+
+	return strings.ToLower(string(runes[:max(idx, 1)])) + string(runes[max(idx, 1):])
+}
+
+// Field is a single property of a model.
+type Field struct {
+	// Key is the name of the property in the record.
+	Key string
+	// GoName is the name of the field in the client type.
+	GoName string
+	// Schema is the Go expression for the property's Schema.
+	Schema string
+	// Kind describes how to serialize the field.
+	Kind FieldKind
+	// Ref is the model to serialize the field with, for refs and arrays of refs.
+	Ref string
+}
+
+// Local is the name of the variable holding the serialized field, which mustn't clash
+// with a keyword or the names the Serialize method already uses.
+func (f Field) Local() string {
+	name := strings.ToLower(f.GoName[:1]) + f.GoName[1:]
+	if token.IsKeyword(name) || name == "input" || name == "client" || name == "lo" {
+		name += "Value"
+	}
+
+	return name
+}
+
+type FieldKind string
+
+var (
+	FieldKindValue        FieldKind = "value"
+	FieldKindRef          FieldKind = "ref"
+	FieldKindOptionalRef  FieldKind = "optional_ref"
+	FieldKindRefs         FieldKind = "refs"
+	FieldKindOptionalRefs FieldKind = "optional_refs"
+)
+
+// Build resolves the models for the roots and everything they reference, in name order.
+func Build(spec Spec, overrides Overrides) ([]Model, error) {
+	models := map[string]Model{}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if _, ok := models[name]; ok {
+			return nil
+		}
+
+		schema, ok := spec.Components.Schemas[name]
+		if !ok {
+			return errors.Errorf("no component called %s", name)
+		}
+
+		model, refs, err := buildModel(name, schema, overrides.Schemas[name])
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+		models[name] = model
+
+		for _, ref := range refs {
+			if err := visit(ref); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, root := range overrides.Roots {
+		if err := visit(root); err != nil {
+			return nil, err
+		}
+	}
+
+	for name := range overrides.Schemas {
+		if _, ok := models[name]; !ok {
+			return nil, errors.Errorf("overrides provided for %s, which isn't generated", name)
+		}
+	}
+
+	result := []Model{}
+	for _, model := range models {
+		result = append(result, model)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func buildModel(name string, schema Schema, override SchemaOverride) (Model, []string, error) {
+	if schema.Type != "object" {
+		return Model{}, nil, errors.Errorf("unsupported component type %q", schema.Type)
+	}
+
+	model := Model{Name: name}
+	refs := []string{}
+
+	keys := make([]string, 0, len(schema.Properties))
+	for key := range schema.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range override.Exclude {
+		if _, ok := schema.Properties[key]; !ok {
+			return Model{}, nil, errors.Errorf("cannot exclude %s, which isn't a property", key)
+		}
+	}
+	for key := range override.Rename {
+		if _, ok := schema.Properties[key]; !ok {
+			return Model{}, nil, errors.Errorf("cannot rename %s, which isn't a property", key)
+		}
+	}
+
+	for _, key := range keys {
+		property := schema.Properties[key]
+		if contains(override.Exclude, key) {
+			continue
+		}
+		if !override.IncludeDeprecated && strings.HasPrefix(strings.ToUpper(property.Description), "DEPRECATED") {
+			continue
+		}
+
+		optional := property.Nullable || !contains(schema.Required, key)
+		field := Field{Key: key, GoName: ToCamelCase(key)}
+		if rename, ok := override.Rename[key]; ok {
+			field.Key = rename
+		}
+
+		switch {
+		case property.Ref != "":
+			field.Ref = property.RefName()
+			field.Schema = fmt.Sprintf("%s.Schema()", field.Ref)
+			field.Kind = FieldKindRef
+			if optional {
+				field.Kind = FieldKindOptionalRef
+			}
+			refs = append(refs, field.Ref)
+
+		case property.Type == "array" && property.Items != nil && property.Items.Ref != "":
+			field.Ref = property.Items.RefName()
+			field.Schema = fmt.Sprintf("ArrayOf(%s.Schema())", field.Ref)
+			field.Kind = FieldKindRefs
+			if optional {
+				field.Kind = FieldKindOptionalRefs
+			}
+			refs = append(refs, field.Ref)
+			model.UsesLo = true
+
+		case property.Type == "array" && property.Items != nil && isScalar(property.Items.Type):
+			field.Schema = fmt.Sprintf(`Property{Types: []string{"array"}, Items: &ArrayItem{Type: %q}}`, property.Items.Type)
+			field.Kind = FieldKindValue
+
+		case property.Type == "string" && property.Format == "date-time":
+			field.Schema = "DateTime.Schema()"
+			field.Kind = FieldKindValue
+
+		case isScalar(property.Type):
+			field.Schema = fmt.Sprintf(`Property{Types: []string{%q}}`, property.Type)
+			field.Kind = FieldKindValue
+
+		default:
+			return Model{}, nil, errors.Errorf("unsupported property %s of type %q", key, property.Type)
+		}
+
+		if optional {
+			field.Schema = fmt.Sprintf("Optional(%s)", field.Schema)
+		} else {
+			// Property literals don't need their type inside the map.
+			field.Schema = strings.TrimPrefix(field.Schema, "Property")
+		}
+
+		model.Properties = append(model.Properties, field)
+	}
+
+	return model, refs, nil
+}
+
+func isScalar(kind string) bool {
+	return kind == "string" || kind == "integer" || kind == "number" || kind == "boolean"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// separators are the characters oapi-codegen treats as word boundaries when naming
+// fields, which we must match to reference them.
+const separators = "-#@!$&=.+:;_~ (){}[]"
+
+// ToCamelCase converts a property name to the field name oapi-codegen gives it, e.g.
+// slack_user_id becomes SlackUserId.
+func ToCamelCase(str string) string {
+	var (
+		result  strings.Builder
+		capNext = true
+	)
+	for _, r := range strings.TrimSpace(str) {
+		switch {
+		case unicode.IsUpper(r), unicode.IsDigit(r):
+			result.WriteRune(r)
+		case unicode.IsLower(r) && capNext:
+			result.WriteRune(unicode.ToUpper(r))
+		case unicode.IsLower(r):
+			result.WriteRune(r)
+		}
+		capNext = strings.ContainsRune(separators, r)
+	}
+
+	return result.String()
+}
+
+var modelTemplate = template.Must(template.New("model").Parse(`// Code generated by modelgen from the OpenAPI spec. DO NOT EDIT.
+
+package model
+
+import (
+	"github.com/incident-io/singer-tap/client"
+{{- if .UsesLo }}
+	"github.com/samber/lo"
+{{- end }}
+)
+
+type {{ .Receiver }} struct{}
+
+var {{ .Name }} {{ .Receiver }}
+
+func ({{ .Receiver }}) Schema() Property {
+	return Property{
+		Types: []string{"object"},
+		Properties: map[string]Property{
+{{- range .Properties }}
+			"{{ .Key }}": {{ .Schema }},
+{{- end }}
+		},
+	}
+}
+
+func ({{ .Receiver }}) Serialize(input client.{{ .Name }}) map[string]any {
+{{- range .Properties }}
+{{- if eq .Kind "optional_ref" }}
+	var {{ .Local }} map[string]any
+	if input.{{ .GoName }} != nil {
+		{{ .Local }} = {{ .Ref }}.Serialize(*input.{{ .GoName }})
+	}
+{{ else if eq .Kind "optional_refs" }}
+	var {{ .Local }} []map[string]any
+	if input.{{ .GoName }} != nil {
+		{{ .Local }} = lo.Map(*input.{{ .GoName }}, func(element client.{{ .Ref }}, _ int) map[string]any {
+			return {{ .Ref }}.Serialize(element)
+		})
+	}
+{{ end }}
+{{- end }}
+	return map[string]any{
+{{- range .Properties }}
+{{- if eq .Kind "value" }}
+		"{{ .Key }}": input.{{ .GoName }},
+{{- else if eq .Kind "ref" }}
+		"{{ .Key }}": {{ .Ref }}.Serialize(input.{{ .GoName }}),
+{{- else if eq .Kind "refs" }}
+		"{{ .Key }}": lo.Map(input.{{ .GoName }}, func(element client.{{ .Ref }}, _ int) map[string]any {
+			return {{ .Ref }}.Serialize(element)
+		}),
+{{- else }}
+		"{{ .Key }}": {{ .Local }},
+{{- end }}
+{{- end }}
+	}
+}
+`))
+
+// Render returns the formatted source of a model.
+func Render(model Model) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := modelTemplate.Execute(&buf, model); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "formatting:\n%s", buf.String())
+	}
+
+	return source, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("modelgen", func() {
+	DescribeTable("ToCamelCase matches oapi-codegen",
+		func(property, field string) {
+			Expect(ToCamelCase(property)).To(Equal(field))
+		},
+		Entry("snake case", "slack_user_id", "SlackUserId"),
+		Entry("single word", "id", "Id"),
+		Entry("digits", "value_v2", "ValueV2"),
+	)
+
+	DescribeTable("FileName",
+		func(name, file string) {
+			Expect(Model{Name: name}.FileName()).To(Equal(file))
+		},
+		Entry("simple", "IncidentV2", "incident_v2.gen.go"),
+		Entry("leading acronym", "APIKeyV2", "api_key_v2.gen.go"),
+		Entry("trailing acronym", "RBACRoleV2", "rbac_role_v2.gen.go"),
+	)
+
+	Describe("Build", func() {
+		var spec Spec
+
+		BeforeEach(func() {
+			spec.Components.Schemas = map[string]Schema{
+				"ThingV1": {
+					Type:     "object",
+					Required: []string{"id", "owner"},
+					Properties: map[string]Schema{
+						"id":     {Type: "string"},
+						"owner":  {Ref: "#/components/schemas/OwnerV1"},
+						"secret": {Type: "string"},
+						"legacy": {Type: "string", Description: "DEPRECATED: use id"},
+						"tags":   {Type: "array", Items: &Schema{Type: "string"}},
+					},
+				},
+				"OwnerV1": {
+					Type:       "object",
+					Properties: map[string]Schema{"name": {Type: "string"}},
+				},
+			}
+		})
+
+		It("generates referenced models, applying overrides", func() {
+			models, err := Build(spec, Overrides{
+				Roots: []string{"ThingV1"},
+				Schemas: map[string]SchemaOverride{
+					"ThingV1": {Exclude: []string{"secret"}, Rename: map[string]string{"tags": "labels"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(models).To(HaveLen(2))
+
+			thing := models[1]
+			Expect(thing.Name).To(Equal("ThingV1"))
+			Expect(thing.Properties).To(ConsistOf(
+				SatisfyAll(HaveField("Key", "id"), HaveField("Schema", `{Types: []string{"string"}}`)),
+				SatisfyAll(HaveField("Key", "owner"), HaveField("Kind", FieldKindRef)),
+				SatisfyAll(HaveField("Key", "labels"), HaveField("GoName", "Tags"), HaveField("Schema", HavePrefix("Optional("))),
+			))
+		})
+
+		It("rejects overrides for properties that don't exist", func() {
+			_, err := Build(spec, Overrides{
+				Roots:   []string{"ThingV1"},
+				Schemas: map[string]SchemaOverride{"ThingV1": {Exclude: []string{"missing"}}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("cannot exclude missing")))
+		})
+	})
+
+	It("matches the generated models", func() {
+		var (
+			spec      Spec
+			overrides Overrides
+		)
+		Expect(readJSON("../../client/openapi3.json", &spec)).To(Succeed())
+		Expect(readJSON("../../model/overrides.json", &overrides)).To(Succeed())
+
+		models, err := Build(spec, overrides)
+		Expect(err).NotTo(HaveOccurred())
+
+		generated, err := filepath.Glob("../../model/*.gen.go")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated).To(HaveLen(len(models)), "run make generate to update the models")
+
+		for _, model := range models {
+			source, err := Render(model)
+			Expect(err).NotTo(HaveOccurred())
+
+			existing, err := os.ReadFile(filepath.Join("../../model", model.FileName()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(existing)).To(Equal(string(source)), "run make generate to update %s", model.FileName())
+		}
+	})
+})
@@ -0,0 +1,5 @@
+package model
+
+// The Schema and Serialize methods of each API type are generated from the OpenAPI spec,
+// customised by overrides.json.
+//go:generate go run ../internal/modelgen -spec ../client/openapi3.json -overrides overrides.json -out .
@@ -0,0 +1,101 @@
+package tap
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/samber/lo"
+)
+
+// Capabilities are the Singer features the tap supports, as declared to Meltano.
+var Capabilities = []string{"catalog", "discover", "state", "properties"}
+
+// About describes the tap, its settings and streams, in the format the Meltano Singer
+// SDK uses for --about so plugin registries can read it.
+type About struct {
+	Name         string                `json:"name"`
+	Description  string                `json:"description"`
+	Version      string                `json:"version"`
+	Capabilities []string              `json:"capabilities"`
+	Settings     *config.SettingSchema `json:"settings"`
+	Streams      []AboutStream         `json:"streams"`
+}
+
+// AboutStream is a stream the tap can sync.
+type AboutStream struct {
+	Name              string   `json:"name"`
+	KeyProperties     []string `json:"key_properties"`
+	SelectedByDefault bool     `json:"selected_by_default"`
+}
+
+// NewAbout describes this version of the tap.
+func NewAbout(version string) *About {
+	about := &About{
+		Name:         "tap-incident",
+		Description:  "Extract data from incident.io for use with Singer",
+		Version:      version,
+		Capabilities: Capabilities,
+		Settings:     config.Settings(),
+		Streams:      []AboutStream{},
+	}
+
+	for _, entry := range NewDefaultCatalog(streams).Streams {
+		output := streams[entry.Stream].Output()
+		about.Streams = append(about.Streams, AboutStream{
+			Name:              entry.Stream,
+			KeyProperties:     output.KeyProperties,
+			SelectedByDefault: !optionalStreams[entry.Stream],
+		})
+	}
+
+	return about
+}
+
+// Markdown renders the description for humans, such as in a README or plugin registry.
+func (a *About) Markdown() string {
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# `%s`\n\n%s\n\nVersion: %s\n\n", a.Name, a.Description, a.Version)
+
+	md.WriteString("## Capabilities\n\n")
+	for _, capability := range a.Capabilities {
+		fmt.Fprintf(&md, "- `%s`\n", capability)
+	}
+
+	md.WriteString("\n## Settings\n\n")
+	md.WriteString("| Setting | Type | Required | Default | Description |\n")
+	md.WriteString("| --- | --- | --- | --- | --- |\n")
+	names := lo.Keys(a.Settings.Properties)
+	sort.Strings(names)
+	for _, name := range names {
+		setting := a.Settings.Properties[name]
+
+		description := setting.Description
+		if len(setting.Enum) > 0 {
+			description += fmt.Sprintf(" One of: %s.", strings.Join(lo.Map(setting.Enum, func(value any, _ int) string {
+				return fmt.Sprintf("`%v`", value)
+			}), ", "))
+		}
+
+		defaultValue := ""
+		if setting.Default != nil {
+			data, _ := json.Marshal(setting.Default)
+			defaultValue = fmt.Sprintf("`%s`", data)
+		}
+
+		fmt.Fprintf(&md, "| `%s` | %s | %t | %s | %s |\n", name, strings.Join(setting.Type, ", "),
+			lo.Contains(a.Settings.Required, name), defaultValue, strings.TrimSpace(description))
+	}
+
+	md.WriteString("\n## Streams\n\n")
+	md.WriteString("| Stream | Key properties | Selected by default |\n")
+	md.WriteString("| --- | --- | --- |\n")
+	for _, stream := range a.Streams {
+		fmt.Fprintf(&md, "| `%s` | %s | %t |\n", stream.Name, strings.Join(stream.KeyProperties, ", "), stream.SelectedByDefault)
+	}
+
+	return md.String()
+}
@@ -0,0 +1,33 @@
+package tap_test
+
+import (
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("About", func() {
+	It("lists every stream in the catalog", func() {
+		about := tap.NewAbout("1.2.3")
+		Expect(about.Version).To(Equal("1.2.3"))
+		Expect(about.Capabilities).To(ConsistOf("catalog", "discover", "state", "properties"))
+
+		incidents, ok := lo.Find(about.Streams, func(stream tap.AboutStream) bool { return stream.Name == "incidents" })
+		Expect(ok).To(BeTrue())
+		Expect(incidents).To(Equal(tap.AboutStream{Name: "incidents", KeyProperties: []string{"id"}, SelectedByDefault: true}))
+
+		metrics, ok := lo.Find(about.Streams, func(stream tap.AboutStream) bool { return stream.Name == "incident_metrics" })
+		Expect(ok).To(BeTrue())
+		Expect(metrics.SelectedByDefault).To(BeFalse())
+	})
+
+	It("renders markdown", func() {
+		md := tap.NewAbout("1.2.3").Markdown()
+		Expect(md).To(ContainSubstring("- `properties`"))
+		Expect(md).To(ContainSubstring("| `api_key` | string | true |  |"))
+		Expect(md).To(ContainSubstring("| `log_format` | string | false | `\"logfmt\"` |"))
+		Expect(md).To(ContainSubstring("| `incident_metrics` | incident_id | false |"))
+	})
+})
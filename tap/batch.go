@@ -0,0 +1,153 @@
+package tap
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/pkg/errors"
+)
+
+// BatchWriter writes the records of a stream to files, outputting a BATCH message that
+// references each file once it's complete. Any other message is passed through to the
+// output logger, after completing the current file so messages stay in order.
+//
+// This is much faster than a RECORD message per record for large streams, both for us
+// and the target.
+type BatchWriter struct {
+	ol       *OutputLogger
+	cfg      config.BatchConfig
+	dir      string
+	stream   string
+	encoding config.BatchEncoding
+	started  time.Time
+
+	// The file we're currently writing, if any.
+	file    *os.File
+	writer  io.WriteCloser
+	encoder *json.Encoder
+	records int
+	files   int
+}
+
+func NewBatchWriter(ol *OutputLogger, cfg config.BatchConfig, stream string) (*BatchWriter, error) {
+	dir, err := cfg.Storage.Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "creating batch directory")
+	}
+
+	encoding := cfg.Encoding
+	if encoding.Compression == "" {
+		encoding.Compression = config.BatchCompressionGzip
+	}
+
+	return &BatchWriter{
+		ol:       ol,
+		cfg:      cfg,
+		dir:      dir,
+		stream:   stream,
+		encoding: encoding,
+		started:  time.Now(),
+	}, nil
+}
+
+// Log writes records to the current file, starting a new one when it's full, and
+// outputs any other message.
+func (b *BatchWriter) Log(op *Output) error {
+	if op.Type != OutputTypeRecord {
+		if err := b.Close(); err != nil {
+			return err
+		}
+
+		return b.ol.Log(op)
+	}
+
+	if err := b.ol.observe(op); err != nil {
+		return err
+	}
+
+	if b.file == nil {
+		if err := b.open(); err != nil {
+			return err
+		}
+	}
+
+	if err := b.encoder.Encode(op.Record); err != nil {
+		return errors.Wrap(err, "writing record to batch")
+	}
+
+	b.records++
+	if b.records >= b.cfg.Size() {
+		return b.Close()
+	}
+
+	return nil
+}
+
+// Close completes the current file, if any, and outputs its BATCH message.
+func (b *BatchWriter) Close() error {
+	if b.file == nil {
+		return nil
+	}
+
+	if err := b.writer.Close(); err != nil {
+		return errors.Wrap(err, "completing batch")
+	}
+	if err := b.file.Close(); err != nil {
+		return errors.Wrap(err, "closing batch file")
+	}
+
+	path, err := filepath.Abs(b.file.Name())
+	if err != nil {
+		return err
+	}
+	b.file, b.writer, b.encoder, b.records = nil, nil, nil, 0
+
+	return b.ol.Log(&Output{
+		Type:     OutputTypeBatch,
+		Stream:   b.stream,
+		Encoding: &b.encoding,
+		Manifest: []string{(&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()},
+	})
+}
+
+func (b *BatchWriter) open() error {
+	b.files++
+
+	name := fmt.Sprintf("%s%s-%d-%04d.jsonl", b.cfg.Storage.Prefix, b.stream, b.started.UnixMilli(), b.files)
+	if b.encoding.Compression == config.BatchCompressionGzip {
+		name += ".gz"
+	}
+
+	file, err := os.Create(filepath.Join(b.dir, name))
+	if err != nil {
+		return errors.Wrap(err, "creating batch file")
+	}
+
+	b.file = file
+	if b.encoding.Compression == config.BatchCompressionGzip {
+		b.writer = gzip.NewWriter(file)
+	} else {
+		b.writer = nopCloser{file}
+	}
+	b.encoder = json.NewEncoder(b.writer)
+
+	return nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
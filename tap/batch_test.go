@@ -0,0 +1,125 @@
+package tap_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"net/url"
+	"os"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Batches", func() {
+	var (
+		fake  *fakeTap
+		cfg   config.Config
+		state *tap.State
+	)
+
+	BeforeEach(func() {
+		state = nil
+		cfg = config.Config{
+			BatchConfig: &config.BatchConfig{
+				Encoding:  config.BatchEncoding{Format: config.BatchFormatJSONL},
+				Storage:   config.BatchStorage{Root: "file://" + GinkgoT().TempDir(), Prefix: "incident-"},
+				BatchSize: 4,
+			},
+		}
+
+		fake = newFakeTap(fakeapi.Generate(1, fakeapi.Size{Users: 10, CustomFields: 2}), map[string]tap.Stream{
+			"users":         &tap.StreamUsers{},
+			"custom_fields": &tap.StreamCustomFields{},
+		})
+	})
+
+	sync := func() []tap.Output {
+		return fake.SyncOutputs(state, cfg)
+	}
+
+	// read decompresses the records from each file in a BATCH message.
+	read := func(op tap.Output) []map[string]any {
+		records := []map[string]any{}
+		for _, uri := range op.Manifest {
+			parsed, err := url.Parse(uri)
+			Expect(err).NotTo(HaveOccurred())
+
+			file, err := os.Open(parsed.Path)
+			Expect(err).NotTo(HaveOccurred())
+			defer file.Close()
+
+			reader, err := gzip.NewReader(file)
+			Expect(err).NotTo(HaveOccurred())
+
+			scanner := bufio.NewScanner(reader)
+			for scanner.Scan() {
+				var record map[string]any
+				Expect(json.Unmarshal(scanner.Bytes(), &record)).To(Succeed())
+				records = append(records, record)
+			}
+			Expect(scanner.Err()).NotTo(HaveOccurred())
+		}
+
+		return records
+	}
+
+	It("outputs BATCH messages in place of records", func() {
+		users := lo.Filter(sync(), func(op tap.Output, _ int) bool {
+			return op.Stream == "users"
+		})
+		Expect(users).To(HaveEach(HaveField("Type", Not(Equal(tap.OutputTypeRecord)))))
+
+		batches := lo.Filter(users, func(op tap.Output, _ int) bool {
+			return op.Type == tap.OutputTypeBatch
+		})
+		Expect(batches).To(HaveLen(3))
+		Expect(batches).To(HaveEach(HaveField("Encoding", Equal(&config.BatchEncoding{Format: "jsonl", Compression: "gzip"}))))
+		Expect(batches[0].Manifest).To(ConsistOf(MatchRegexp(`^file:///.+/incident-users-\d+-0001\.jsonl\.gz$`)))
+
+		records := lo.FlatMap(batches, func(op tap.Output, _ int) []map[string]any { return read(op) })
+		Expect(records).To(HaveLen(10))
+		Expect(lo.Uniq(lo.Map(records, func(record map[string]any, _ int) any { return record["id"] }))).To(HaveLen(10))
+	})
+
+	It("outputs records of streams with table versions directly, keeping their version", func() {
+		state = &tap.State{Bookmarks: map[string]tap.Bookmark{"custom_fields": {Version: 1}}}
+		outputs := sync()
+
+		customFields := lo.Filter(outputs, func(op tap.Output, _ int) bool {
+			return op.Stream == "custom_fields"
+		})
+		Expect(lo.Map(customFields, func(op tap.Output, _ int) tap.OutputType { return op.Type })).To(Equal([]tap.OutputType{
+			tap.OutputTypeSchema,
+			tap.OutputTypeRecord,
+			tap.OutputTypeRecord,
+			tap.OutputTypeActivateVersion,
+		}))
+
+		version := customFields[len(customFields)-1].Version
+		Expect(version).NotTo(BeNil())
+		Expect(*version).To(BeNumerically(">", 1))
+		Expect(customFields[1:]).To(HaveEach(HaveField("Version", Equal(version))))
+	})
+
+	It("batches full-table streams in soft-delete mode", func() {
+		cfg.Streams = map[string]config.StreamConfig{"custom_fields": {SoftDelete: true}}
+		state = &tap.State{Bookmarks: map[string]tap.Bookmark{"custom_fields": {IDs: []string{"01DELETED"}}}}
+		outputs := sync()
+
+		batches := lo.Filter(outputs, func(op tap.Output, _ int) bool {
+			return op.Type == tap.OutputTypeBatch && op.Stream == "custom_fields"
+		})
+		records := lo.FlatMap(batches, func(op tap.Output, _ int) []map[string]any { return read(op) })
+		Expect(records).To(HaveLen(3))
+		Expect(records).To(ContainElement(SatisfyAll(
+			HaveKeyWithValue("id", "01DELETED"),
+			HaveKeyWithValue(tap.DeletedAtProperty, Not(BeNil())),
+		)))
+	})
+})
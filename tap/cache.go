@@ -0,0 +1,64 @@
+package tap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/incident-io/singer-tap/config"
+)
+
+type syncCacheKey struct{}
+
+// syncCache holds responses that more than one stream is built from, such as the list
+// of incidents, so they are only loaded once per sync.
+type syncCache struct {
+	mu      sync.Mutex
+	entries map[string]any
+}
+
+// withSyncCache returns a context that shares loaded responses between streams.
+func withSyncCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, syncCacheKey{}, &syncCache{entries: map[string]any{}})
+}
+
+// cached returns the result of load, only calling it the first time it's asked for the
+// key within a sync. Outside of a sync, it always calls load.
+func cached[T any](ctx context.Context, key string, load func() (T, error)) (T, error) {
+	cache, ok := ctx.Value(syncCacheKey{}).(*syncCache)
+	if !ok {
+		return load()
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if entry, ok := cache.entries[key]; ok {
+		return entry.(T), nil
+	}
+
+	result, err := load()
+	if err != nil {
+		return result, err
+	}
+	cache.entries[key] = result
+
+	return result, nil
+}
+
+// cacheKey identifies a listing by what changes its results: its filters, and its page
+// size and max_pages when it stops early. Listings that differ only in other options,
+// such as their timeout, are shared.
+func cacheKey(name string, opts config.StreamConfig, filters any) string {
+	key := name
+	if opts.MaxPages > 0 {
+		key += fmt.Sprintf(" page_size=%d max_pages=%d", opts.PageSize, opts.MaxPages)
+	}
+	if filters != nil {
+		data, _ := json.Marshal(filters)
+		key += " filters=" + string(data)
+	}
+
+	return key
+}
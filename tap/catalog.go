@@ -2,7 +2,9 @@ package tap
 
 import (
 	"cmp"
+	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/incident-io/singer-tap/model"
 )
@@ -44,14 +46,7 @@ func (c *Catalog) GetEnabledStreams() []CatalogEntry {
 					continue
 				}
 
-				// Check if the metadata has the user input "selected" bool
-				if metadata.Metadata.Selected != nil {
-					// If so, check its set to true!
-					if *metadata.Metadata.Selected {
-						enabledStreams = append(enabledStreams, entry)
-					}
-					// otherwise check if WE have set to select this by default
-				} else if metadata.Metadata.SelectedByDefault {
+				if metadata.Metadata.IsSelected() {
 					enabledStreams = append(enabledStreams, entry)
 				}
 			}
@@ -65,6 +60,11 @@ func (c *CatalogEntry) GetDisabledFields() map[string]bool {
 	// Just something to enable quick lookups of fields by name
 	var disabledFields = map[string]bool{}
 
+	// Without metadata every field is enabled
+	if c.Metadata == nil {
+		return disabledFields
+	}
+
 	// For the given stream, get the enabled fields
 	// For this catalog entry, get the metadata, and build a list of all the enabled fields
 	for _, metadata := range *c.Metadata {
@@ -73,29 +73,66 @@ func (c *CatalogEntry) GetDisabledFields() map[string]bool {
 			continue
 		}
 
-		// Check if the metadata has the user input "selected" bool
-		if metadata.Metadata.Selected != nil {
-			// If so, check its set to false!
-			if !*metadata.Metadata.Selected {
-				disabledFields[metadata.Breadcrumb[len(metadata.Breadcrumb)-1]] = true
+		if !metadata.Metadata.IsSelected() {
+			disabledFields[metadata.Breadcrumb[len(metadata.Breadcrumb)-1]] = true
+		}
+	}
+
+	return disabledFields
+}
+
+// ValidateSelection returns an error if any enabled stream deselects a field that must
+// always be synced, such as its primary key, without which targets can't upsert the
+// records. That's either a field the catalog marks as automatic, or one we'd mark as
+// automatic if discovered now, for catalogs generated before we did.
+func (c *Catalog) ValidateSelection(streams map[string]Stream) error {
+	var deselected []string
+	for _, entry := range c.GetEnabledStreams() {
+		stream, ok := streams[entry.Stream]
+		if !ok || entry.Metadata == nil {
+			continue
+		}
+
+		automatic := automaticFields(stream.Output())
+		for _, metadata := range *entry.Metadata {
+			if len(metadata.Breadcrumb) == 0 {
+				continue
 			}
-		} else {
-			// There's no selected key, so check if WE have set the selected by default
-			if !metadata.Metadata.SelectedByDefault {
-				disabledFields[metadata.Breadcrumb[len(metadata.Breadcrumb)-1]] = true
+
+			name := metadata.Breadcrumb[len(metadata.Breadcrumb)-1]
+			if metadata.Metadata.Inclusion == InclusionAutomatic {
+				// We'd sync these anyway, but the catalog says not to
+				if metadata.Metadata.Selected != nil && !*metadata.Metadata.Selected {
+					deselected = append(deselected, entry.Stream+"."+name)
+				}
+			} else if automatic[name] && !metadata.Metadata.IsSelected() {
+				deselected = append(deselected, entry.Stream+"."+name)
 			}
 		}
 	}
 
-	return disabledFields
+	if len(deselected) > 0 {
+		return fmt.Errorf("catalog deselects the key or replication key of a stream, which are always synced: %s (select them, or regenerate the catalog with catalog --merge)",
+			strings.Join(deselected, ", "))
+	}
+
+	return nil
 }
 
 func NewDefaultCatalog(streams map[string]Stream) *Catalog {
 	entries := []CatalogEntry{}
 
 	for name, stream := range streams {
-		streamSchema := *stream.Output().Schema
-		metadata := Metadata{}.DefaultMetadata(streamSchema)
+		output := stream.Output()
+		streamSchema := *output.Schema
+		metadata := Metadata{}.DefaultMetadata(output)
+		if optionalStreams[name] {
+			for idx := range metadata {
+				if len(metadata[idx].Breadcrumb) == 0 {
+					metadata[idx].Metadata.SelectedByDefault = false
+				}
+			}
+		}
 
 		// Sort our metadata to make it deterministic
 		slices.SortFunc(metadata, func(i, j Metadata) int {
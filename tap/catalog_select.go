@@ -0,0 +1,165 @@
+package tap
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// CatalogSelection chooses which streams and fields of a catalog are synced, so a
+// catalog can be generated from the command line rather than edited by hand.
+//
+// Patterns are either a stream, such as "incidents", or a field of a stream, such as
+// "users.email", and may use wildcards as in "incidents.*". Selections are applied
+// first, then deselections, then exclusions.
+type CatalogSelection struct {
+	// Select streams or fields. Selecting a field also selects its stream.
+	Select []string
+	// Deselect streams or fields. Fields that are always synced, such as the key of a
+	// stream, can't be deselected by name and are skipped by wildcards.
+	Deselect []string
+	// Exclude removes streams from the catalog entirely.
+	Exclude []string
+}
+
+// Apply updates the catalog with the selection, returning an error if any pattern
+// matches nothing, as it's most likely a typo.
+func (c *Catalog) Apply(selection CatalogSelection) error {
+	for _, pattern := range selection.Select {
+		if err := c.setSelected(pattern, true); err != nil {
+			return err
+		}
+	}
+
+	for _, pattern := range selection.Deselect {
+		if err := c.setSelected(pattern, false); err != nil {
+			return err
+		}
+	}
+
+	for _, pattern := range selection.Exclude {
+		if strings.Contains(pattern, ".") {
+			return fmt.Errorf("can't exclude %q, only whole streams can be excluded", pattern)
+		}
+
+		entries := lo.Reject(c.Streams, func(entry CatalogEntry, _ int) bool {
+			return matchPattern(pattern, entry.Stream)
+		})
+		if len(entries) == len(c.Streams) {
+			return fmt.Errorf("no streams match %q", pattern)
+		}
+
+		c.Streams = entries
+	}
+
+	return nil
+}
+
+func (c *Catalog) setSelected(pattern string, selected bool) error {
+	streamPattern, fieldPattern, isField := strings.Cut(pattern, ".")
+
+	matched := false
+	for _, entry := range c.Streams {
+		if !matchPattern(streamPattern, entry.Stream) || entry.Metadata == nil {
+			continue
+		}
+
+		for idx := range *entry.Metadata {
+			metadata := &(*entry.Metadata)[idx]
+
+			if len(metadata.Breadcrumb) == 0 {
+				// Selecting a field of a stream means we want the stream too
+				if !isField || selected {
+					metadata.Metadata.Selected = lo.ToPtr(selected)
+					matched = matched || !isField
+				}
+
+				continue
+			}
+
+			name := metadata.Breadcrumb[len(metadata.Breadcrumb)-1]
+			if !isField || !matchPattern(fieldPattern, name) {
+				continue
+			}
+
+			if !selected && metadata.Metadata.Inclusion == InclusionAutomatic {
+				if fieldPattern == name {
+					return fmt.Errorf("can't deselect %s.%s, it's the key or replication key of the stream so is always synced", entry.Stream, name)
+				}
+
+				continue
+			}
+
+			metadata.Metadata.Selected = lo.ToPtr(selected)
+			matched = true
+		}
+	}
+
+	if !matched {
+		return fmt.Errorf("no streams or fields match %q", pattern)
+	}
+
+	return nil
+}
+
+// matchPattern returns whether the name matches the pattern, which may use wildcards.
+func matchPattern(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// Merge carries over what was selected in an existing catalog, so it can be regenerated
+// with the live schema without losing any changes made to it.
+//
+// Deselected keys aren't carried over, as Sync refuses them. Streams that aren't in the
+// existing catalog, either because it excluded them or they were added since, are
+// deselected, so regenerating a catalog never syncs anything new.
+func (c *Catalog) Merge(existing *Catalog) {
+	existingEntries := lo.KeyBy(existing.Streams, func(entry CatalogEntry) string {
+		return entry.TapStreamID
+	})
+	enabled := lo.KeyBy(existing.GetEnabledStreams(), func(entry CatalogEntry) string {
+		return entry.TapStreamID
+	})
+
+	for _, entry := range c.Streams {
+		if entry.Metadata == nil {
+			continue
+		}
+
+		existingEntry, ok := existingEntries[entry.TapStreamID]
+
+		// Fields are matched by breadcrumb, ignoring any that no longer exist
+		existingFields := map[string]MetadataFields{}
+		if ok && existingEntry.Metadata != nil {
+			for _, metadata := range *existingEntry.Metadata {
+				existingFields[strings.Join(metadata.Breadcrumb, ".")] = metadata.Metadata
+			}
+		}
+
+		for idx := range *entry.Metadata {
+			metadata := &(*entry.Metadata)[idx]
+
+			if len(metadata.Breadcrumb) == 0 {
+				_, isEnabled := enabled[entry.TapStreamID]
+				metadata.Metadata.Selected = lo.ToPtr(isEnabled)
+				continue
+			}
+
+			fields, ok := existingFields[strings.Join(metadata.Breadcrumb, ".")]
+			if !ok || fields.Selected == nil {
+				continue
+			}
+
+			// Catalogs from before keys were automatic may have deselected them, which we
+			// no longer allow
+			if metadata.Metadata.Inclusion == InclusionAutomatic && !*fields.Selected {
+				continue
+			}
+
+			metadata.Metadata.Selected = lo.ToPtr(*fields.Selected)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tap_test
+
+import (
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CatalogSelection", func() {
+	var (
+		catalog *tap.Catalog
+	)
+
+	BeforeEach(func() {
+		catalog = tap.NewDefaultCatalog(map[string]tap.Stream{
+			"alerts":                    &tap.StreamAlerts{},
+			"incidents":                 &tap.StreamIncidents{},
+			"incident_role_assignments": &tap.StreamIncidentRoleAssignments{},
+			"users":                     &tap.StreamUsers{},
+		})
+	})
+
+	enabledStreams := func() []string {
+		return lo.Map(catalog.GetEnabledStreams(), func(entry tap.CatalogEntry, _ int) string {
+			return entry.Stream
+		})
+	}
+
+	disabledFields := func(stream string) map[string]bool {
+		entry, _ := lo.Find(catalog.Streams, func(entry tap.CatalogEntry) bool {
+			return entry.Stream == stream
+		})
+
+		return entry.GetDisabledFields()
+	}
+
+	Describe("Apply", func() {
+		It("selects, deselects and excludes streams and fields", func() {
+			Expect(catalog.Apply(tap.CatalogSelection{
+				Select:   []string{"incident_role_assignments.*"},
+				Deselect: []string{"users.email", "incidents"},
+				Exclude:  []string{"alerts"},
+			})).To(Succeed())
+
+			Expect(lo.Map(catalog.Streams, func(entry tap.CatalogEntry, _ int) string {
+				return entry.Stream
+			})).To(Equal([]string{"incident_role_assignments", "incidents", "users"}))
+			Expect(enabledStreams()).To(Equal([]string{"incident_role_assignments", "users"}))
+			Expect(disabledFields("users")).To(Equal(map[string]bool{"email": true}))
+		})
+
+		It("applies deselections after selections", func() {
+			Expect(catalog.Apply(tap.CatalogSelection{
+				Select:   []string{"users.*"},
+				Deselect: []string{"users.e*"},
+			})).To(Succeed())
+
+			Expect(disabledFields("users")).To(Equal(map[string]bool{"email": true}))
+		})
+
+		It("skips keys when deselecting by wildcard", func() {
+			Expect(catalog.Apply(tap.CatalogSelection{Deselect: []string{"users.*"}})).To(Succeed())
+
+			Expect(disabledFields("users")).NotTo(HaveKey("id"))
+			Expect(catalog.ValidateSelection(map[string]tap.Stream{"users": &tap.StreamUsers{}})).To(Succeed())
+		})
+
+		It("refuses to deselect keys by name", func() {
+			Expect(catalog.Apply(tap.CatalogSelection{Deselect: []string{"users.id"}})).To(
+				MatchError(ContainSubstring("can't deselect users.id")))
+		})
+
+		It("returns an error for patterns that match nothing", func() {
+			Expect(catalog.Apply(tap.CatalogSelection{Select: []string{"user.email"}})).To(
+				MatchError(`no streams or fields match "user.email"`))
+			Expect(catalog.Apply(tap.CatalogSelection{Exclude: []string{"alert"}})).To(
+				MatchError(`no streams match "alert"`))
+		})
+	})
+
+	Describe("Merge", func() {
+		var (
+			existing *tap.Catalog
+		)
+
+		BeforeEach(func() {
+			existing = tap.NewDefaultCatalog(map[string]tap.Stream{
+				"incidents":                 &tap.StreamIncidents{},
+				"incident_role_assignments": &tap.StreamIncidentRoleAssignments{},
+				"users":                     &tap.StreamUsers{},
+			})
+			Expect(existing.Apply(tap.CatalogSelection{
+				Select:   []string{"incident_role_assignments"},
+				Deselect: []string{"incidents", "users.email"},
+			})).To(Succeed())
+		})
+
+		It("keeps the selections of the existing catalog", func() {
+			catalog.Merge(existing)
+
+			Expect(enabledStreams()).To(Equal([]string{"incident_role_assignments", "users"}))
+			Expect(disabledFields("users")).To(Equal(map[string]bool{"email": true}))
+		})
+
+		It("deselects streams that weren't in the existing catalog", func() {
+			catalog.Merge(existing)
+
+			Expect(enabledStreams()).NotTo(ContainElement("alerts"))
+		})
+
+		It("drops deselections of keys", func() {
+			for idx, metadata := range *existing.Streams[2].Metadata {
+				if len(metadata.Breadcrumb) > 0 && metadata.Breadcrumb[1] == "id" {
+					(*existing.Streams[2].Metadata)[idx].Metadata.Inclusion = tap.InclusionAvailable
+					(*existing.Streams[2].Metadata)[idx].Metadata.Selected = lo.ToPtr(false)
+				}
+			}
+
+			catalog.Merge(existing)
+
+			Expect(disabledFields("users")).NotTo(HaveKey("id"))
+		})
+	})
+})
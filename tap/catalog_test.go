@@ -0,0 +1,138 @@
+package tap_test
+
+import (
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Catalog", func() {
+	field := func(name string, fields tap.MetadataFields) tap.Metadata {
+		return tap.Metadata{Breadcrumb: []string{"properties", name}, Metadata: fields}
+	}
+
+	Describe("GetDisabledFields", func() {
+		It("disables deselected fields and those not selected by default", func() {
+			entry := tap.CatalogEntry{Metadata: &[]tap.Metadata{
+				{Breadcrumb: []string{}, Metadata: tap.MetadataFields{Inclusion: tap.InclusionAvailable}},
+				field("name", tap.MetadataFields{Inclusion: tap.InclusionAvailable, Selected: lo.ToPtr(false), SelectedByDefault: true}),
+				field("description", tap.MetadataFields{Inclusion: tap.InclusionAvailable}),
+				field("rank", tap.MetadataFields{Inclusion: tap.InclusionAvailable, SelectedByDefault: true}),
+			}}
+
+			Expect(entry.GetDisabledFields()).To(Equal(map[string]bool{"name": true, "description": true}))
+		})
+
+		It("never disables automatic fields, even if deselected", func() {
+			entry := tap.CatalogEntry{Metadata: &[]tap.Metadata{
+				field("id", tap.MetadataFields{Inclusion: tap.InclusionAutomatic, Selected: lo.ToPtr(false)}),
+			}}
+
+			Expect(entry.GetDisabledFields()).To(BeEmpty())
+		})
+
+		It("always disables unsupported fields, even if selected", func() {
+			entry := tap.CatalogEntry{Metadata: &[]tap.Metadata{
+				field("secret", tap.MetadataFields{Inclusion: tap.InclusionUnsupported, Selected: lo.ToPtr(true), SelectedByDefault: true}),
+			}}
+
+			Expect(entry.GetDisabledFields()).To(Equal(map[string]bool{"secret": true}))
+		})
+
+		It("enables every field without metadata", func() {
+			Expect((&tap.CatalogEntry{}).GetDisabledFields()).To(BeEmpty())
+		})
+	})
+
+	Describe("NewDefaultCatalog", func() {
+		It("always includes the key of each stream", func() {
+			catalog := tap.NewDefaultCatalog(map[string]tap.Stream{"users": &tap.StreamUsers{}})
+			Expect(catalog.Streams).To(HaveLen(1))
+
+			metadata := *catalog.Streams[0].Metadata
+			Expect(metadata[0].Metadata).To(Equal(tap.MetadataFields{
+				Inclusion:               tap.InclusionAvailable,
+				SelectedByDefault:       true,
+				ForcedReplicationMethod: tap.ReplicationMethodFullTable,
+				TableKeyProperties:      []string{"id"},
+				ValidReplicationKeys:    []string{},
+			}))
+
+			inclusions := map[string]string{}
+			for _, field := range metadata[1:] {
+				inclusions[field.Breadcrumb[1]] = field.Metadata.Inclusion
+			}
+			Expect(inclusions).To(HaveKeyWithValue("id", tap.InclusionAutomatic))
+			Expect(inclusions).To(HaveKeyWithValue("email", tap.InclusionAvailable))
+		})
+	})
+
+	Describe("ValidateSelection", func() {
+		var (
+			streams = map[string]tap.Stream{"users": &tap.StreamUsers{}}
+			catalog *tap.Catalog
+		)
+
+		BeforeEach(func() {
+			catalog = tap.NewDefaultCatalog(streams)
+		})
+
+		deselect := func(name string, update func(*tap.MetadataFields)) {
+			for idx, metadata := range *catalog.Streams[0].Metadata {
+				if len(metadata.Breadcrumb) > 0 && metadata.Breadcrumb[1] == name {
+					update(&(*catalog.Streams[0].Metadata)[idx].Metadata)
+				}
+			}
+		}
+
+		It("allows deselecting other fields", func() {
+			deselect("email", func(fields *tap.MetadataFields) { fields.Selected = lo.ToPtr(false) })
+
+			Expect(catalog.ValidateSelection(streams)).To(Succeed())
+		})
+
+		It("refuses deselecting automatic fields", func() {
+			deselect("id", func(fields *tap.MetadataFields) { fields.Selected = lo.ToPtr(false) })
+
+			Expect(catalog.ValidateSelection(streams)).To(MatchError(ContainSubstring("users.id")))
+		})
+
+		It("refuses catalogs discovered before keys were automatic that deselect them", func() {
+			deselect("id", func(fields *tap.MetadataFields) {
+				fields.Inclusion = tap.InclusionAvailable
+				fields.Selected = lo.ToPtr(false)
+			})
+
+			Expect(catalog.ValidateSelection(streams)).To(MatchError(ContainSubstring("users.id")))
+		})
+
+		It("ignores streams that aren't selected", func() {
+			deselect("id", func(fields *tap.MetadataFields) { fields.Selected = lo.ToPtr(false) })
+			(*catalog.Streams[0].Metadata)[0].Metadata.Selected = lo.ToPtr(false)
+
+			Expect(catalog.ValidateSelection(streams)).To(Succeed())
+		})
+	})
+
+	Describe("GetEnabledStreams", func() {
+		stream := func(name string, fields tap.MetadataFields) tap.CatalogEntry {
+			return tap.CatalogEntry{Stream: name, Metadata: &[]tap.Metadata{{Breadcrumb: []string{}, Metadata: fields}}}
+		}
+
+		It("respects selection and inclusion of each stream", func() {
+			catalog := tap.Catalog{Streams: []tap.CatalogEntry{
+				stream("default", tap.MetadataFields{Inclusion: tap.InclusionAvailable, SelectedByDefault: true}),
+				stream("deselected", tap.MetadataFields{Inclusion: tap.InclusionAvailable, Selected: lo.ToPtr(false), SelectedByDefault: true}),
+				stream("automatic", tap.MetadataFields{Inclusion: tap.InclusionAutomatic, Selected: lo.ToPtr(false)}),
+				stream("unsupported", tap.MetadataFields{Inclusion: tap.InclusionUnsupported, Selected: lo.ToPtr(true)}),
+				{Stream: "no-metadata"},
+			}}
+
+			Expect(lo.Map(catalog.GetEnabledStreams(), func(entry tap.CatalogEntry, _ int) string {
+				return entry.Stream
+			})).To(Equal([]string{"default", "automatic", "no-metadata"}))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package tap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/incident-io/singer-tap/model"
+)
+
+// SchemaChangeKind describes how a property differs between a catalog and the live
+// schema.
+type SchemaChangeKind string
+
+var (
+	SchemaChangeAdded         SchemaChangeKind = "added"
+	SchemaChangeRemoved       SchemaChangeKind = "removed"
+	SchemaChangeTypeChanged   SchemaChangeKind = "type_changed"
+	SchemaChangeStreamRemoved SchemaChangeKind = "stream_removed"
+)
+
+// SchemaChange is a difference between the schema of a stream in a catalog and the
+// schema the tap would output for that stream today.
+type SchemaChange struct {
+	Stream string           `json:"stream"`
+	Kind   SchemaChangeKind `json:"kind"`
+	// Path is the location of the property, e.g. "severity.rank", where "[]" marks the
+	// items of an array.
+	Path string `json:"path,omitempty"`
+	// From and To describe the property type in the catalog and the live schema.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+func (c SchemaChange) String() string {
+	switch c.Kind {
+	case SchemaChangeStreamRemoved:
+		return fmt.Sprintf("%s: stream no longer exists", c.Stream)
+	case SchemaChangeTypeChanged:
+		return fmt.Sprintf("%s: %s changed from %s to %s", c.Stream, c.Path, c.From, c.To)
+	default:
+		return fmt.Sprintf("%s: %s %s", c.Stream, c.Path, c.Kind)
+	}
+}
+
+// CatalogDrift compares the schema of each enabled stream in the catalog against the
+// stream's live schema. Catalogs are often generated once and kept, so this tells us
+// which properties were added or removed by the API since, or changed type.
+func CatalogDrift(catalog *Catalog) []SchemaChange {
+	changes := []SchemaChange{}
+	for _, entry := range catalog.GetEnabledStreams() {
+		stream, ok := streams[entry.Stream]
+		if !ok {
+			changes = append(changes, SchemaChange{Stream: entry.Stream, Kind: SchemaChangeStreamRemoved})
+			continue
+		}
+
+		changes = append(changes, DiffSchema(entry.Stream, entry.Schema, *stream.Output().Schema)...)
+	}
+
+	return changes
+}
+
+// DiffSchema returns every property that differs between two schemas of a stream,
+// ordered by path.
+func DiffSchema(stream string, from, to model.Schema) []SchemaChange {
+	changes := diffProperties(stream, "", from.Properties, to.Properties)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+func diffProperties(stream, path string, from, to map[string]model.Property) []SchemaChange {
+	changes := []SchemaChange{}
+	for name, before := range from {
+		after, ok := to[name]
+		if !ok {
+			changes = append(changes, SchemaChange{Stream: stream, Kind: SchemaChangeRemoved, Path: join(path, name), From: describe(before)})
+			continue
+		}
+
+		if describe(before) != describe(after) {
+			changes = append(changes, SchemaChange{
+				Stream: stream, Kind: SchemaChangeTypeChanged, Path: join(path, name), From: describe(before), To: describe(after),
+			})
+		}
+
+		changes = append(changes, diffProperties(stream, join(path, name), before.Properties, after.Properties)...)
+		if before.Items != nil && after.Items != nil {
+			changes = append(changes, diffProperties(stream, join(path, name)+"[]", before.Items.Properties, after.Items.Properties)...)
+		}
+	}
+
+	for name, after := range to {
+		if _, ok := from[name]; !ok {
+			changes = append(changes, SchemaChange{Stream: stream, Kind: SchemaChangeAdded, Path: join(path, name), To: describe(after)})
+		}
+	}
+
+	return changes
+}
+
+// describe summarises the type of a property, ignoring the order types are listed in,
+// e.g. "null|string (date-time)".
+func describe(property model.Property) string {
+	types := slices.Clone(property.Types)
+	sort.Strings(types)
+
+	description := strings.Join(types, "|")
+	if property.Items != nil {
+		description += fmt.Sprintf(" of %s", property.Items.Type)
+	}
+	if property.CustomFormat != "" {
+		description += fmt.Sprintf(" (%s)", property.CustomFormat)
+	}
+
+	return description
+}
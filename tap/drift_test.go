@@ -0,0 +1,97 @@
+package tap_test
+
+import (
+	"github.com/incident-io/singer-tap/model"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffSchema", func() {
+	var (
+		from, to model.Schema
+	)
+
+	BeforeEach(func() {
+		from = model.Schema{
+			Type: []string{"object"},
+			Properties: map[string]model.Property{
+				"id":   {Types: []string{"string"}},
+				"rank": {Types: []string{"integer"}},
+				"owner": {
+					Types: []string{"object"},
+					Properties: map[string]model.Property{
+						"name": {Types: []string{"string"}},
+					},
+				},
+				"tags": model.ArrayOf(model.Property{
+					Properties: map[string]model.Property{
+						"label": {Types: []string{"string"}},
+					},
+				}),
+			},
+		}
+		to = model.Schema{
+			Type: []string{"object"},
+			Properties: map[string]model.Property{
+				"id":   {Types: []string{"string"}},
+				"rank": {Types: []string{"null", "number"}},
+				"owner": {
+					Types: []string{"object"},
+					Properties: map[string]model.Property{
+						"name":  {Types: []string{"string"}},
+						"email": {Types: []string{"string"}},
+					},
+				},
+				"tags": model.ArrayOf(model.Property{
+					Properties: map[string]model.Property{},
+				}),
+				"created_at": model.DateTime.Schema(),
+			},
+		}
+	})
+
+	It("reports added, removed and type-changed properties, including nested ones", func() {
+		Expect(tap.DiffSchema("things", from, to)).To(Equal([]tap.SchemaChange{
+			{Stream: "things", Kind: tap.SchemaChangeAdded, Path: "created_at", To: "string (date-time)"},
+			{Stream: "things", Kind: tap.SchemaChangeAdded, Path: "owner.email", To: "string"},
+			{Stream: "things", Kind: tap.SchemaChangeTypeChanged, Path: "rank", From: "integer", To: "null|number"},
+			{Stream: "things", Kind: tap.SchemaChangeRemoved, Path: "tags[].label", From: "string"},
+		}))
+	})
+
+	It("ignores the order of types", func() {
+		from.Properties = map[string]model.Property{"rank": {Types: []string{"integer", "null"}}}
+		to.Properties = map[string]model.Property{"rank": {Types: []string{"null", "integer"}}}
+
+		Expect(tap.DiffSchema("things", from, to)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("CatalogDrift", func() {
+	It("reports streams that no longer exist", func() {
+		catalog := &tap.Catalog{Streams: []tap.CatalogEntry{{Stream: "retired", TapStreamID: "retired"}}}
+		Expect(tap.CatalogDrift(catalog)).To(ConsistOf(tap.SchemaChange{Stream: "retired", Kind: tap.SchemaChangeStreamRemoved}))
+	})
+
+	It("reports nothing when the catalog matches", func() {
+		live := (&tap.StreamUsers{}).Output().Schema
+		catalog := &tap.Catalog{Streams: []tap.CatalogEntry{{Stream: "users", TapStreamID: "users", Schema: *live}}}
+
+		Expect(tap.CatalogDrift(catalog)).To(BeEmpty())
+	})
+
+	It("reports properties that changed since the catalog was generated", func() {
+		live := (&tap.StreamUsers{}).Output().Schema
+
+		schema := *live
+		schema.Properties = lo.OmitByKeys(live.Properties, []string{"email"})
+		catalog := &tap.Catalog{Streams: []tap.CatalogEntry{{Stream: "users", TapStreamID: "users", Schema: schema}}}
+
+		Expect(tap.CatalogDrift(catalog)).To(ConsistOf(
+			SatisfyAll(HaveField("Kind", tap.SchemaChangeAdded), HaveField("Path", "email")),
+		))
+	})
+})
@@ -0,0 +1,76 @@
+package tap
+
+import (
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+// FlattenSeparator joins the names of nested properties, e.g. "severity__name".
+const FlattenSeparator = "__"
+
+// Flattener unnests nested objects into top-level properties named after their path,
+// so severity.name becomes severity__name, as the Singer SDK does when
+// flattening_enabled is set. Many targets store nested objects as opaque JSON, which is
+// awkward to query.
+//
+// Arrays are left as they are, as are objects without declared properties.
+type Flattener struct {
+	// MaxDepth is how many levels of nesting to flatten, where objects below it are kept
+	// as they are. Zero flattens everything.
+	MaxDepth int
+}
+
+// Schema returns the flattened properties. Properties of a nullable object are nullable
+// themselves, as they'll be null whenever the object is.
+func (f Flattener) Schema(properties map[string]model.Property) map[string]model.Property {
+	flattened := map[string]model.Property{}
+	f.flattenSchema(flattened, "", properties, 1, false)
+
+	return flattened
+}
+
+func (f Flattener) flattenSchema(flattened map[string]model.Property, prefix string, properties map[string]model.Property, depth int, nullable bool) {
+	for name, property := range properties {
+		if nullable {
+			property = model.Optional(property)
+		}
+
+		if !f.flattens(property, depth) {
+			flattened[prefix+name] = property
+			continue
+		}
+
+		f.flattenSchema(flattened, prefix+name+FlattenSeparator, property.Properties, depth+1, lo.Contains(property.Types, "null"))
+	}
+}
+
+// Record flattens a record according to the schema it was serialized from. Every
+// flattened property is present, being nil when any object above it is nil.
+func (f Flattener) Record(properties map[string]model.Property, record map[string]any) map[string]any {
+	flattened := map[string]any{}
+	f.flattenRecord(flattened, "", properties, record, 1)
+
+	return flattened
+}
+
+func (f Flattener) flattenRecord(flattened map[string]any, prefix string, properties map[string]model.Property, record map[string]any, depth int) {
+	for name, value := range record {
+		property, ok := properties[name]
+		if !ok || !f.flattens(property, depth) {
+			flattened[prefix+name] = value
+			continue
+		}
+
+		// A nil object still produces its properties, which will all be nil.
+		object, _ := value.(map[string]any)
+		if object == nil {
+			object = lo.MapValues(property.Properties, func(model.Property, string) any { return nil })
+		}
+
+		f.flattenRecord(flattened, prefix+name+FlattenSeparator, property.Properties, object, depth+1)
+	}
+}
+
+func (f Flattener) flattens(property model.Property, depth int) bool {
+	return len(property.Properties) > 0 && (f.MaxDepth == 0 || depth <= f.MaxDepth)
+}
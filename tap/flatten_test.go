@@ -0,0 +1,115 @@
+package tap_test
+
+import (
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/incident-io/singer-tap/tap"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flattener", func() {
+	var (
+		flattener  tap.Flattener
+		properties map[string]model.Property
+	)
+
+	BeforeEach(func() {
+		flattener = tap.Flattener{}
+		properties = map[string]model.Property{
+			"id": {Types: []string{"string"}},
+			"severity": model.Optional(model.Property{
+				Types: []string{"object"},
+				Properties: map[string]model.Property{
+					"name": {Types: []string{"string"}},
+				},
+			}),
+			"creator": {
+				Types: []string{"object"},
+				Properties: map[string]model.Property{
+					"user": {
+						Types: []string{"object"},
+						Properties: map[string]model.Property{
+							"name": {Types: []string{"string"}},
+						},
+					},
+				},
+			},
+			"tags": model.ArrayOf(model.Property{Types: []string{"string"}}),
+		}
+	})
+
+	It("flattens the schema, making properties of nullable objects nullable", func() {
+		Expect(flattener.Schema(properties)).To(Equal(map[string]model.Property{
+			"id":                  {Types: []string{"string"}},
+			"severity__name":      model.Optional(model.Property{Types: []string{"string"}}),
+			"creator__user__name": {Types: []string{"string"}},
+			"tags":                model.ArrayOf(model.Property{Types: []string{"string"}}),
+		}))
+	})
+
+	It("flattens records, including nil objects", func() {
+		record := map[string]any{
+			"id":       "01INC",
+			"severity": map[string]any(nil),
+			"creator":  map[string]any{"user": map[string]any{"name": "Lisa"}},
+			"tags":     []string{"a"},
+		}
+
+		Expect(flattener.Record(properties, record)).To(Equal(map[string]any{
+			"id":                  "01INC",
+			"severity__name":      nil,
+			"creator__user__name": "Lisa",
+			"tags":                []string{"a"},
+		}))
+	})
+
+	Context("with a max depth", func() {
+		BeforeEach(func() {
+			flattener.MaxDepth = 1
+		})
+
+		It("keeps objects below it as they are", func() {
+			Expect(flattener.Schema(properties)).To(HaveKeyWithValue("creator__user", properties["creator"].Properties["user"]))
+
+			record := flattener.Record(properties, map[string]any{
+				"creator": map[string]any{"user": map[string]any{"name": "Lisa"}},
+			})
+			Expect(record).To(Equal(map[string]any{
+				"creator__user": map[string]any{"name": "Lisa"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("Flattened streams", func() {
+	It("output records matching their flattened schema", func() {
+		fake := newFakeTap(fakeapi.Generate(1, fakeapi.Size{
+			Incidents: 10, UpdatesPerIncident: 1, AttachmentsPerIncident: 1,
+		}), map[string]tap.Stream{"incidents": &tap.StreamIncidents{}})
+
+		stream := tap.Filter{
+			Stream:       &tap.StreamIncidents{},
+			CatalogEntry: fake.Catalog.Streams[0],
+			Flattener:    &tap.Flattener{},
+		}
+
+		output := stream.Output()
+		Expect(output.Schema.Properties).To(HaveKey("severity__name"))
+		Expect(output.Schema.Properties).NotTo(HaveKey("severity"))
+
+		validator := tap.NewRecordValidator()
+		Expect(validator.Observe(output)).To(Succeed())
+
+		records, err := stream.GetRecords(fake.Context, fake.Logger, fake.Client, config.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).NotTo(BeEmpty())
+
+		for _, record := range records {
+			Expect(validator.Validate("incidents", record)).To(Succeed())
+		}
+		Expect(validator.Violations()).To(BeEmpty())
+	})
+})
@@ -1,7 +1,19 @@
 package tap
 
-import (
-	"github.com/incident-io/singer-tap/model"
+// Inclusion says whether a stream or field is emitted, as set in the discovered catalog.
+const (
+	// InclusionAvailable is emitted unless deselected.
+	InclusionAvailable = "available"
+	// InclusionAutomatic is always emitted, even if deselected.
+	InclusionAutomatic = "automatic"
+	// InclusionUnsupported is never emitted, even if selected.
+	InclusionUnsupported = "unsupported"
+)
+
+// Replication methods a stream can be synced with.
+const (
+	ReplicationMethodFullTable   = "FULL_TABLE"
+	ReplicationMethodIncremental = "INCREMENTAL"
 )
 
 type Metadata struct {
@@ -48,33 +60,73 @@ type MetadataFields struct {
 	// This really only applies to available inclusion setting
 	SelectedByDefault bool `json:"selected-by-default,omitempty"`
 
-	// ForcedReplicateMethod: we will set to FULL_TABLE for our tap
+	// ForcedReplicateMethod: FULL_TABLE for our tap, unless the stream has a replication key
 	ForcedReplicationMethod string `json:"forced-replication-method,omitempty"`
+
+	// TableKeyProperties: the primary key of the stream, as in its SCHEMA messages
+	TableKeyProperties []string `json:"table-key-properties,omitempty"`
+
+	// ValidReplicationKeys: the properties the stream can be bookmarked by
+	ValidReplicationKeys []string `json:"valid-replication-keys,omitempty"`
+}
+
+// IsSelected returns whether the stream or field this metadata applies to is emitted.
+// Automatic ones always are, so key properties such as id can't be deselected, and
+// unsupported ones never are. Otherwise we use the user's "selected", falling back to
+// whether we select it by default.
+func (m MetadataFields) IsSelected() bool {
+	switch m.Inclusion {
+	case InclusionAutomatic:
+		return true
+	case InclusionUnsupported:
+		return false
+	}
+
+	if m.Selected != nil {
+		return *m.Selected
+	}
+
+	return m.SelectedByDefault
 }
 
-func (m Metadata) DefaultMetadata(schema model.Schema) []Metadata {
+func (m Metadata) DefaultMetadata(output *Output) []Metadata {
+	// Streams with a replication key can be synced incrementally, everything else is
+	// reloaded in full
+	replicationMethod := ReplicationMethodFullTable
+	if len(output.BookmarkProperties) > 0 {
+		replicationMethod = ReplicationMethodIncremental
+	}
+
 	// By default we always include a top level metadata with the same
 	// settings
 	var metadata = []Metadata{
 		{
 			Breadcrumb: []string{},
 			Metadata: MetadataFields{
-				Inclusion:               "available",  // always set to available at stream level
-				SelectedByDefault:       true,         // lets assume people always want our data
-				ForcedReplicationMethod: "FULL_TABLE", // HIGHWAY TO THE DATA ZONE
+				Inclusion:               InclusionAvailable, // always set to available at stream level
+				SelectedByDefault:       true,               // lets assume people always want our data
+				ForcedReplicationMethod: replicationMethod,  // HIGHWAY TO THE DATA ZONE
+				TableKeyProperties:      output.KeyProperties,
+				ValidReplicationKeys:    output.BookmarkProperties,
 			},
 		},
 	}
 
 	// For columns we want to set the inclusion to available for everything - but we set
 	// selected by default to true as well (so unless the user speficially says no, we'll include it)
-	// We might want to get more intelligent later - as this way people could stop themselves
-	// from getting key data by accident
-	for name := range schema.Properties {
+	// The exceptions are the primary key and replication keys, which targets need to upsert
+	// records and we need to bookmark them, so are always included
+	automatic := automaticFields(output)
+	for name := range output.Schema.Properties {
+		inclusion := InclusionAvailable
+		if automatic[name] {
+			inclusion = InclusionAutomatic
+		}
+
 		metadata = append(metadata, Metadata{
 			Breadcrumb: []string{"properties", name},
 			Metadata: MetadataFields{
-				Inclusion:         "available",
+				Inclusion:         inclusion,
 				SelectedByDefault: true,
 			},
 		})
@@ -82,3 +134,17 @@ func (m Metadata) DefaultMetadata(schema model.Schema) []Metadata {
 
 	return metadata
 }
+
+// automaticFields are the fields of a stream that must always be synced: its key
+// properties and replication keys.
+func automaticFields(output *Output) map[string]bool {
+	automatic := map[string]bool{}
+	for _, name := range output.KeyProperties {
+		automatic[name] = true
+	}
+	for _, name := range output.BookmarkProperties {
+		automatic[name] = true
+	}
+
+	return automatic
+}
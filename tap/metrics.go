@@ -0,0 +1,168 @@
+package tap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/incident-io/singer-tap/client"
+)
+
+// MetricType is the kind of Singer metric, which decides how its value is read.
+type MetricType string
+
+var (
+	// MetricTypeTimer values are a duration in seconds.
+	MetricTypeTimer MetricType = "timer"
+	// MetricTypeCounter values are a count of things since the last metric.
+	MetricTypeCounter MetricType = "counter"
+)
+
+// Metric is a Singer metric, which taps log to STDERR for runners such as Meltano to
+// pick up, as described in https://hub.meltano.com/singer/docs#metrics.
+//
+// We output:
+//
+// - http_request_duration: a timer for every attempt at an API request, tagged with
+// the endpoint, stream, http_status_code, attempt (counting from one) and status of
+// "succeeded" or "failed".
+// - record_count: a counter of the records output for each stream.
+// - sync_duration: a timer for how long each stream took to sync.
+type Metric struct {
+	Type   MetricType     `json:"type"`
+	Metric string         `json:"metric"`
+	Value  any            `json:"value"`
+	Tags   map[string]any `json:"tags"`
+}
+
+// MetricExporter receives every metric, along with how much we output, such as to
+// expose them to Prometheus.
+type MetricExporter interface {
+	Export(metric Metric)
+	// Written is called with the size of each message we output.
+	Written(op *Output, bytes int)
+}
+
+// WithMetrics logs Singer metrics to w, usually STDERR.
+func (o *OutputLogger) WithMetrics(w io.Writer) *OutputLogger {
+	o.metrics = w
+	return o
+}
+
+// WithExporter passes every metric, and the size of every message we output, to the
+// exporter.
+func (o *OutputLogger) WithExporter(exporter MetricExporter) *OutputLogger {
+	o.exporter = exporter
+	return o
+}
+
+// Metric logs a Singer metric, if metrics are enabled, and exports it if we have an
+// exporter.
+func (o *OutputLogger) Metric(metric Metric) error {
+	if o.exporter != nil {
+		o.exporter.Export(metric)
+	}
+
+	if o.metrics == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(metric)
+	if err != nil {
+		return err
+	}
+
+	o.metricsMu.Lock()
+	defer o.metricsMu.Unlock()
+
+	_, err = fmt.Fprintf(o.metrics, "INFO METRIC: %s\n", string(data))
+	return err
+}
+
+// StreamSummary is what it took to sync a stream.
+type StreamSummary struct {
+	Stream  string
+	Records int
+	// Pages is how many pages were loaded from paginated endpoints.
+	Pages int
+	// Requests is how many API requests were made, not counting retries.
+	Requests int
+	// Retries is how many times requests were attempted again after failing.
+	Retries int
+	Elapsed time.Duration
+}
+
+type streamStatsKey struct{}
+
+// streamStats collects the summary of a stream while it syncs.
+type streamStats struct {
+	mu      sync.Mutex
+	summary StreamSummary
+	started time.Time
+}
+
+// withStreamStats returns a context that collects stats for the stream, observing the
+// requests made with it to output an http_request_duration metric for each attempt.
+func withStreamStats(ctx context.Context, ol *OutputLogger, stream string) (context.Context, *streamStats) {
+	stats := &streamStats{summary: StreamSummary{Stream: stream}, started: time.Now()}
+
+	ctx = context.WithValue(ctx, streamStatsKey{}, stats)
+	ctx = client.WithRequestObserver(ctx, func(attempt client.Attempt) {
+		stats.add(func(summary *StreamSummary) {
+			if attempt.Retry() {
+				summary.Retries++
+			} else {
+				summary.Requests++
+			}
+		})
+
+		status := "succeeded"
+		if !attempt.Succeeded() {
+			status = "failed"
+		}
+
+		// Failing to log a metric shouldn't fail the sync.
+		_ = ol.Metric(Metric{
+			Type:   MetricTypeTimer,
+			Metric: "http_request_duration",
+			Value:  attempt.Duration.Seconds(),
+			Tags: map[string]any{
+				"endpoint":         attempt.Request.URL.Path,
+				"stream":           stream,
+				"http_status_code": attempt.StatusCode,
+				"attempt":          attempt.Number + 1,
+				"status":           status,
+			},
+		})
+	})
+
+	return ctx, stats
+}
+
+// countPage adds a loaded page to the stats of the stream being synced, if there is one.
+func countPage(ctx context.Context) {
+	if stats, ok := ctx.Value(streamStatsKey{}).(*streamStats); ok {
+		stats.add(func(summary *StreamSummary) { summary.Pages++ })
+	}
+}
+
+func (s *streamStats) add(update func(*StreamSummary)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&s.summary)
+}
+
+// finish returns the summary of the stream once its records have been output.
+func (s *streamStats) finish(records int) StreamSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.summary.Records = records
+	s.summary.Elapsed = time.Since(s.started)
+
+	return s.summary
+}
@@ -0,0 +1,103 @@
+package tap_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	var (
+		metrics, logs bytes.Buffer
+		opts          []fakeapi.Option
+	)
+
+	BeforeEach(func() {
+		metrics.Reset()
+		logs.Reset()
+		opts = nil
+	})
+
+	JustBeforeEach(func() {
+		fake := newFakeTap(fakeapi.Generate(1, fakeapi.Size{Users: 60}), map[string]tap.Stream{"users": &tap.StreamUsers{}}, opts...)
+		fake.Logger = kitlog.NewLogfmtLogger(&logs)
+
+		ol := tap.NewOutputLogger(io.Discard).WithMetrics(&metrics)
+		Expect(fake.Sync(ol, nil, config.Config{Streams: map[string]config.StreamConfig{"users": {PageSize: 25}}})).To(Succeed())
+	})
+
+	parse := func() []tap.Metric {
+		parsed := []tap.Metric{}
+		scanner := bufio.NewScanner(&metrics)
+		for scanner.Scan() {
+			line, ok := strings.CutPrefix(scanner.Text(), "INFO METRIC: ")
+			Expect(ok).To(BeTrue(), "metric lines should be prefixed as Singer expects")
+
+			var metric tap.Metric
+			Expect(json.Unmarshal([]byte(line), &metric)).To(Succeed())
+			parsed = append(parsed, metric)
+		}
+
+		return parsed
+	}
+
+	It("outputs a timer for every request, and a count of records and duration per stream", func() {
+		parsed := parse()
+
+		timers := lo.Filter(parsed, func(metric tap.Metric, _ int) bool {
+			return metric.Metric == "http_request_duration"
+		})
+		Expect(timers).To(HaveLen(3))
+		Expect(timers[0].Type).To(Equal(tap.MetricTypeTimer))
+		Expect(timers[0].Tags).To(Equal(map[string]any{
+			"endpoint":         "/v2/users",
+			"stream":           "users",
+			"http_status_code": float64(200),
+			"attempt":          float64(1),
+			"status":           "succeeded",
+		}))
+
+		Expect(parsed).To(ContainElement(tap.Metric{
+			Type:   tap.MetricTypeCounter,
+			Metric: "record_count",
+			Value:  float64(60),
+			Tags:   map[string]any{"stream": "users"},
+		}))
+		Expect(parsed[len(parsed)-1].Metric).To(Equal("sync_duration"))
+	})
+
+	It("logs a summary of each stream", func() {
+		Expect(logs.String()).To(MatchRegexp(`msg="synced stream" stream=users records=60 pages=3 requests=3 retries=0 elapsed=\S+`))
+	})
+
+	When("a request fails once", func() {
+		BeforeEach(func() {
+			opts = append(opts, fakeapi.WithFault(fakeapi.Fault{Path: "/v2/users", Status: http.StatusInternalServerError, Skip: 1, Times: 1}))
+		})
+
+		It("retries it, outputting a timer for each attempt", func() {
+			timers := lo.Filter(parse(), func(metric tap.Metric, _ int) bool {
+				return metric.Metric == "http_request_duration"
+			})
+			Expect(timers).To(HaveLen(4))
+			Expect(timers[1].Tags).To(HaveKeyWithValue("status", "failed"))
+			Expect(timers[1].Tags).To(HaveKeyWithValue("http_status_code", float64(500)))
+			Expect(timers[2].Tags).To(HaveKeyWithValue("status", "succeeded"))
+			Expect(timers[2].Tags).To(HaveKeyWithValue("attempt", float64(2)))
+
+			Expect(logs.String()).To(MatchRegexp(`msg="synced stream" stream=users records=60 pages=3 requests=3 retries=1 `))
+		})
+	})
+})
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 )
 
@@ -14,16 +16,22 @@ type OutputType string
 var (
 	OutputTypeSchema OutputType = "SCHEMA"
 	OutputTypeRecord OutputType = "RECORD"
+	OutputTypeState  OutputType = "STATE"
+	OutputTypeBatch  OutputType = "BATCH"
+
+	OutputTypeActivateVersion OutputType = "ACTIVATE_VERSION"
 )
 
 // Output is what we log to STDOUT as a message provided to the downstream Singer target.
 //
-// This tap supports type types of output:
+// This tap supports these types of output:
 //
 // - SCHEMA: Specifies the schema of this stream in JSON schema format.
 // - RECORD: A record from the stream.
-//
-// We (currently) do not support the other types of output such as STATE.
+// - ACTIVATE_VERSION: Tells the target a full-table stream has finished loading a new
+// version of its table, so rows from older versions can be removed.
+// - STATE: State to be given back to the tap on the next sync.
+// - BATCH: Files containing records from the stream, in place of RECORD messages.
 type Output struct {
 	// Type is the type of the stream, e.g. "SCHEMA" or "RECORD"
 	Type OutputType `json:"type,omitempty"`
@@ -45,32 +53,101 @@ type Output struct {
 	// BookmarkProperties is an optional list of strings indicating which properties
 	// should be used to bookmark the stream, such as "last_updated_at".
 	BookmarkProperties []string `json:"bookmark_properties,omitempty"`
+	// Version is the table version, if Type == "RECORD" or "ACTIVATE_VERSION" for a
+	// full-table stream.
+	Version *int64 `json:"version,omitempty"`
+	// Value is the state of the tap, if Type == "STATE".
+	Value any `json:"value,omitempty"`
+	// Encoding is the format of the files in the manifest, if Type == "BATCH".
+	Encoding *config.BatchEncoding `json:"encoding,omitempty"`
+	// Manifest is a list of file URIs containing records, if Type == "BATCH".
+	Manifest []string `json:"manifest,omitempty"`
 }
 
 // OutputLogger is a logger that logs to STDOUT in the format expected by the downstream
 // Singer target.
 type OutputLogger struct {
 	w io.Writer
+	// sink, if set, receives every message in place of writing them to w.
+	sink Sink
+	// validator, if set, checks every record we output against its stream's schema.
+	validator *RecordValidator
+	// metrics, if set, receives Singer metrics.
+	metrics   io.Writer
+	metricsMu sync.Mutex
+	// exporter, if set, receives every metric and the size of every message.
+	exporter MetricExporter
 }
 
 func NewOutputLogger(w io.Writer) *OutputLogger {
 	return &OutputLogger{w: w}
 }
 
+// Sink receives the messages we'd otherwise output to a Singer target, for when we
+// write the data somewhere ourselves.
+type Sink interface {
+	Write(op *Output) error
+	// Close is called once every message has been written.
+	Close() error
+}
+
+// NewSinkOutputLogger sends every message to the sink, rather than STDOUT.
+func NewSinkOutputLogger(sink Sink) *OutputLogger {
+	return &OutputLogger{sink: sink}
+}
+
+// WithValidator checks each record we output against the schema of its stream,
+// collecting any violations in the validator.
+func (o *OutputLogger) WithValidator(validator *RecordValidator) *OutputLogger {
+	o.validator = validator
+	return o
+}
+
 func (o *OutputLogger) Log(op *Output) error {
+	if err := o.observe(op); err != nil {
+		return err
+	}
+
+	if o.sink != nil {
+		return o.sink.Write(op)
+	}
+
 	data, err := json.Marshal(op)
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintln(o.w, string(data))
+	written, err := fmt.Fprintln(o.w, string(data))
 	if err != nil {
 		return err
 	}
 
+	if o.exporter != nil {
+		o.exporter.Written(op, written)
+	}
+
 	return nil
 }
 
+// Close completes the output, which is needed when writing to a sink.
+func (o *OutputLogger) Close() error {
+	if o.sink == nil {
+		return nil
+	}
+
+	return o.sink.Close()
+}
+
+// observe passes a message to the validator, if we have one. Messages we don't log
+// ourselves, such as records written to batch files, must still be observed.
+func (o *OutputLogger) observe(op *Output) error {
+	if o.validator == nil {
+		return nil
+	}
+
+	return o.validator.Observe(op)
+}
+
 func (o *OutputLogger) CataLog(catalog *Catalog) error {
 	data, err := json.Marshal(catalog)
 	if err != nil {
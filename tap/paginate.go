@@ -0,0 +1,162 @@
+package tap
+
+import (
+	"context"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Endpoint describes an incident.io API list endpoint that a stream loads from.
+type Endpoint struct {
+	// Name identifies the endpoint in logs, e.g. "incidents".
+	Name string
+	// MaxPageSize is the largest page size the endpoint accepts, or zero if the endpoint
+	// is not paginated and returns all results in a single response.
+	MaxPageSize int64
+}
+
+// PageRequest is the page a stream should fetch.
+type PageRequest struct {
+	// After is the cursor to pass as the `after` parameter, nil for the first page.
+	After *string
+	// PageSize is the number of results to ask for, zero if the endpoint is not paginated.
+	PageSize int64
+}
+
+// Page is a single page of results loaded from an endpoint.
+type Page[T any] struct {
+	Results []T
+	// PaginationMeta is the pagination_meta from the response, if there was one.
+	PaginationMeta *PaginationMeta
+}
+
+// PaginationMeta is the subset of the API's pagination_meta that we use to find the
+// next page.
+type PaginationMeta struct {
+	// After is the cursor for the next page, or nil if this was the last page.
+	After *string
+}
+
+// Paginator loads every result from a list endpoint, leaving each stream to declare only
+// how to fetch a single page.
+//
+// When the response includes pagination_meta we trust its `after` cursor to tell us
+// whether there are more pages. Some endpoints don't return it, in which case we use the
+// ID of the last result as the cursor and stop when a page comes back short, which saves
+// requesting an empty page at the end of every sync.
+type Paginator[T any] struct {
+	Endpoint Endpoint
+	// Options configure the page size, request timeout and page limit.
+	Options config.StreamConfig
+	// Fetch loads a single page.
+	Fetch func(ctx context.Context, req PageRequest) (*Page[T], error)
+	// ID returns the ID of a result, used as the cursor when the endpoint doesn't return
+	// pagination_meta. It's not needed for unpaginated endpoints.
+	ID func(T) string
+}
+
+// All loads every page, returning the combined results.
+func (p Paginator[T]) All(ctx context.Context, logger kitlog.Logger) ([]T, error) {
+	var (
+		req     = PageRequest{PageSize: p.pageSize()}
+		seen    = map[string]bool{}
+		results = []T{}
+	)
+
+	logger = kitlog.With(logger, "endpoint", p.Endpoint.Name)
+	for pageNumber := 1; ; pageNumber++ {
+		page, err := p.fetch(ctx, req, pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		countPage(ctx)
+		results = append(results, page.Results...)
+		level.Debug(logger).Log("msg", "loaded page", "page", pageNumber, "page_size", req.PageSize,
+			"after", lo.FromPtr(req.After), "records", len(page.Results), "total", len(results))
+
+		after, more := p.next(req, page)
+		if !more {
+			return results, nil
+		}
+
+		if p.Options.MaxPages > 0 && pageNumber >= p.Options.MaxPages {
+			level.Warn(logger).Log("msg", "stopping before all pages were loaded, as max_pages was reached",
+				"max_pages", p.Options.MaxPages)
+			return results, nil
+		}
+
+		// If the API hands us a cursor we've already followed, we'd loop forever.
+		if seen[*after] {
+			return nil, errors.Errorf("%s: pagination cursor %q repeated after %d pages",
+				p.Endpoint.Name, *after, pageNumber)
+		}
+		seen[*after] = true
+
+		req.After = after
+	}
+}
+
+// pageSize is the configured page size, capped at the most the endpoint allows.
+func (p Paginator[T]) pageSize() int64 {
+	if p.Options.PageSize > 0 {
+		return min(p.Options.PageSize, p.Endpoint.MaxPageSize)
+	}
+
+	return p.Endpoint.MaxPageSize
+}
+
+// fetch loads a single page, applying the configured request timeout.
+func (p Paginator[T]) fetch(ctx context.Context, req PageRequest, pageNumber int) (page *Page[T], err error) {
+	ctx, span := tracer.Start(ctx, "tap.Paginator.fetch", trace.WithAttributes(
+		attribute.String("endpoint", p.Endpoint.Name),
+		attribute.Int("page", pageNumber),
+		attribute.Int64("page_size", req.PageSize),
+		attribute.String("after", lo.FromPtr(req.After)),
+	))
+	defer func() {
+		if page != nil {
+			span.SetAttributes(attribute.Int("records", len(page.Results)))
+		}
+		endSpan(span, err)
+	}()
+
+	if p.Options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(p.Options.Timeout))
+		defer cancel()
+	}
+
+	return p.Fetch(ctx, req)
+}
+
+// next returns the cursor for the page after the one we just loaded, if there is one.
+func (p Paginator[T]) next(req PageRequest, page *Page[T]) (*string, bool) {
+	if req.PageSize == 0 {
+		return nil, false
+	}
+
+	if page.PaginationMeta != nil {
+		after := page.PaginationMeta.After
+		return after, lo.FromPtr(after) != ""
+	}
+
+	if count := len(page.Results); count > 0 && int64(count) >= req.PageSize {
+		return lo.ToPtr(p.ID(page.Results[count-1])), true
+	}
+
+	return nil, false
+}
+
+// childOptions are the options used to load resources related to each record in a
+// stream, which share the stream's timeout but are otherwise loaded in full.
+func childOptions(opts config.StreamConfig) config.StreamConfig {
+	return config.StreamConfig{Timeout: opts.Timeout}
+}
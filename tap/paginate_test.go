@@ -0,0 +1,194 @@
+package tap_test
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paginator", func() {
+	var (
+		ctx      context.Context
+		logger   kitlog.Logger
+		results  []int
+		withMeta bool
+		requests []tap.PageRequest
+	)
+
+	// fetch serves pages of results, using their index as their ID.
+	fetch := func(ctx context.Context, req tap.PageRequest) (*tap.Page[int], error) {
+		requests = append(requests, req)
+
+		from := 0
+		if req.After != nil {
+			from, _ = strconv.Atoi(*req.After)
+			from++
+		}
+		to := min(from+int(req.PageSize), len(results))
+
+		page := &tap.Page[int]{Results: results[from:to]}
+		if withMeta {
+			page.PaginationMeta = &tap.PaginationMeta{}
+			if to < len(results) {
+				page.PaginationMeta.After = lo.ToPtr(strconv.Itoa(to - 1))
+			}
+		}
+
+		return page, nil
+	}
+
+	paginator := func() tap.Paginator[int] {
+		return tap.Paginator[int]{
+			Endpoint: tap.Endpoint{Name: "numbers", MaxPageSize: 10},
+			Fetch:    fetch,
+			ID: func(result int) string {
+				return strconv.Itoa(result)
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		logger = kitlog.NewNopLogger()
+		results = lo.Range(25)
+		requests = nil
+	})
+
+	When("the endpoint returns pagination_meta", func() {
+		BeforeEach(func() {
+			withMeta = true
+		})
+
+		It("follows the after cursor until it's empty", func() {
+			loaded, err := paginator().All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(results))
+			Expect(requests).To(HaveLen(3))
+			Expect(requests[1].After).To(Equal(lo.ToPtr("9")))
+		})
+
+		It("doesn't request an empty page when the last page is full", func() {
+			results = lo.Range(20)
+
+			loaded, err := paginator().All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(HaveLen(20))
+			Expect(requests).To(HaveLen(2))
+		})
+	})
+
+	When("the endpoint doesn't return pagination_meta", func() {
+		BeforeEach(func() {
+			withMeta = false
+		})
+
+		It("uses the last ID as the cursor and stops on a short page", func() {
+			loaded, err := paginator().All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(results))
+			Expect(requests).To(HaveLen(3))
+			Expect(requests[2].After).To(Equal(lo.ToPtr("19")))
+		})
+
+		It("stops on an empty first page", func() {
+			results = []int{}
+
+			loaded, err := paginator().All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(BeEmpty())
+			Expect(requests).To(HaveLen(1))
+		})
+	})
+
+	Describe("options", func() {
+		BeforeEach(func() {
+			withMeta = true
+		})
+
+		It("uses the configured page size", func() {
+			p := paginator()
+			p.Options = config.StreamConfig{PageSize: 5}
+
+			loaded, err := p.All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(HaveLen(25))
+			Expect(requests).To(HaveLen(5))
+			Expect(requests[0].PageSize).To(Equal(int64(5)))
+		})
+
+		It("never exceeds the endpoint maximum", func() {
+			p := paginator()
+			p.Options = config.StreamConfig{PageSize: 100}
+
+			_, err := p.All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].PageSize).To(Equal(int64(10)))
+		})
+
+		It("stops after max pages", func() {
+			p := paginator()
+			p.Options = config.StreamConfig{MaxPages: 2}
+
+			loaded, err := p.All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(HaveLen(20))
+			Expect(requests).To(HaveLen(2))
+		})
+
+		It("applies the timeout to each request", func() {
+			p := paginator()
+			p.Options = config.StreamConfig{Timeout: config.Duration(time.Minute)}
+			p.Fetch = func(ctx context.Context, req tap.PageRequest) (*tap.Page[int], error) {
+				deadline, ok := ctx.Deadline()
+				Expect(ok).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+
+				return &tap.Page[int]{Results: results, PaginationMeta: &tap.PaginationMeta{}}, nil
+			}
+
+			_, err := p.All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the endpoint isn't paginated", func() {
+		It("makes a single request", func() {
+			p := paginator()
+			p.Endpoint.MaxPageSize = 0
+			p.Fetch = func(ctx context.Context, req tap.PageRequest) (*tap.Page[int], error) {
+				requests = append(requests, req)
+				return &tap.Page[int]{Results: results}, nil
+			}
+
+			loaded, err := p.All(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(results))
+			Expect(requests).To(HaveLen(1))
+		})
+	})
+
+	When("the endpoint repeats a cursor", func() {
+		It("errors instead of looping forever", func() {
+			p := paginator()
+			p.Fetch = func(ctx context.Context, req tap.PageRequest) (*tap.Page[int], error) {
+				requests = append(requests, req)
+				return &tap.Page[int]{
+					Results:        results[:10],
+					PaginationMeta: &tap.PaginationMeta{After: lo.ToPtr("9")},
+				}, nil
+			}
+
+			_, err := p.All(ctx, logger)
+			Expect(err).To(MatchError(ContainSubstring(`pagination cursor "9" repeated`)))
+			Expect(requests).To(HaveLen(2))
+		})
+	})
+})
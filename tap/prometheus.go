@@ -0,0 +1,122 @@
+package tap
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusExporter exposes the metrics of a sync in Prometheus format, for when the tap
+// runs somewhere like a Kubernetes CronJob where there's little else to go on.
+//
+// It builds on the Singer metrics we output, so the two always agree.
+type PrometheusExporter struct {
+	registry *prometheus.Registry
+
+	requests      *prometheus.CounterVec
+	requestTime   *prometheus.HistogramVec
+	retries       *prometheus.CounterVec
+	rateLimited   *prometheus.CounterVec
+	records       *prometheus.CounterVec
+	outputBytes   *prometheus.CounterVec
+	streamTime    *prometheus.HistogramVec
+	lastSync      prometheus.Gauge
+	lastSyncState prometheus.Gauge
+}
+
+// NewPrometheusExporter creates an exporter with its own registry, so only our metrics
+// are exposed.
+func NewPrometheusExporter() *PrometheusExporter {
+	e := &PrometheusExporter{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tap_incident_api_requests_total",
+			Help: "Attempts at API requests, including retries, by endpoint and status code.",
+		}, []string{"endpoint", "status_code"}),
+		requestTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "tap_incident_api_request_duration_seconds",
+			Help:    "How long each attempt at an API request took.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tap_incident_api_retries_total",
+			Help: "Attempts at API requests that retried an earlier attempt.",
+		}, []string{"endpoint"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tap_incident_api_rate_limited_total",
+			Help: "API requests rejected with a 429 as we'd exceeded the rate limit.",
+		}, []string{"endpoint"}),
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tap_incident_records_total",
+			Help: "Records output for each stream.",
+		}, []string{"stream"}),
+		outputBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tap_incident_output_bytes_total",
+			Help: "Bytes of Singer messages output, by stream and message type.",
+		}, []string{"stream", "type"}),
+		streamTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "tap_incident_stream_duration_seconds",
+			Help:    "How long each stream took to sync.",
+			Buckets: []float64{1, 5, 15, 30, 60, 300, 600, 1800, 3600},
+		}, []string{"stream"}),
+		lastSync: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "tap_incident_last_sync_timestamp_seconds",
+			Help: "When the last sync finished, as a Unix timestamp.",
+		}),
+		lastSyncState: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "tap_incident_last_sync_success",
+			Help: "Whether the last sync succeeded (1) or failed (0).",
+		}),
+	}
+
+	e.registry.MustRegister(
+		e.requests, e.requestTime, e.retries, e.rateLimited, e.records, e.outputBytes,
+		e.streamTime, e.lastSync, e.lastSyncState,
+	)
+
+	return e
+}
+
+// Registry is where our metrics are registered, to serve or write them.
+func (e *PrometheusExporter) Registry() *prometheus.Registry {
+	return e.registry
+}
+
+func (e *PrometheusExporter) Export(metric Metric) {
+	stream, _ := metric.Tags["stream"].(string)
+
+	switch metric.Metric {
+	case "http_request_duration":
+		endpoint, _ := metric.Tags["endpoint"].(string)
+		statusCode, _ := metric.Tags["http_status_code"].(int)
+		attempt, _ := metric.Tags["attempt"].(int)
+
+		e.requests.WithLabelValues(endpoint, strconv.Itoa(statusCode)).Inc()
+		e.requestTime.WithLabelValues(endpoint).Observe(metric.Value.(float64))
+		if attempt > 1 {
+			e.retries.WithLabelValues(endpoint).Inc()
+		}
+		if statusCode == 429 {
+			e.rateLimited.WithLabelValues(endpoint).Inc()
+		}
+	case "record_count":
+		e.records.WithLabelValues(stream).Add(float64(metric.Value.(int)))
+	case "sync_duration":
+		e.streamTime.WithLabelValues(stream).Observe(metric.Value.(float64))
+	}
+}
+
+func (e *PrometheusExporter) Written(op *Output, bytes int) {
+	e.outputBytes.WithLabelValues(op.Stream, string(op.Type)).Add(float64(bytes))
+}
+
+// Finish records the outcome of the sync.
+func (e *PrometheusExporter) Finish(at time.Time, err error) {
+	e.lastSync.Set(float64(at.Unix()))
+	if err != nil {
+		e.lastSyncState.Set(0)
+	} else {
+		e.lastSyncState.Set(1)
+	}
+}
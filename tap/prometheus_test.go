@@ -0,0 +1,102 @@
+package tap_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusExporter", func() {
+	var (
+		exporter *tap.PrometheusExporter
+		opts     []fakeapi.Option
+	)
+
+	BeforeEach(func() {
+		exporter = tap.NewPrometheusExporter()
+		opts = nil
+	})
+
+	sync := func() error {
+		fake := newFakeTap(fakeapi.Generate(1, fakeapi.Size{Users: 60}), map[string]tap.Stream{"users": &tap.StreamUsers{}}, opts...)
+
+		ol := tap.NewOutputLogger(io.Discard).WithExporter(exporter)
+		err := fake.Sync(ol, nil, config.Config{Streams: map[string]config.StreamConfig{"users": {PageSize: 25}}})
+		exporter.Finish(time.Unix(1700000000, 0), err)
+
+		return err
+	}
+
+	It("counts requests, records and output", func() {
+		Expect(sync()).To(Succeed())
+
+		Expect(testutil.GatherAndCompare(exporter.Registry(), strings.NewReader(`
+# HELP tap_incident_api_requests_total Attempts at API requests, including retries, by endpoint and status code.
+# TYPE tap_incident_api_requests_total counter
+tap_incident_api_requests_total{endpoint="/v2/users",status_code="200"} 3
+# HELP tap_incident_records_total Records output for each stream.
+# TYPE tap_incident_records_total counter
+tap_incident_records_total{stream="users"} 60
+# HELP tap_incident_last_sync_success Whether the last sync succeeded (1) or failed (0).
+# TYPE tap_incident_last_sync_success gauge
+tap_incident_last_sync_success 1
+`), "tap_incident_api_requests_total", "tap_incident_records_total", "tap_incident_last_sync_success")).To(Succeed())
+
+		Expect(testutil.GatherAndCount(exporter.Registry(), "tap_incident_api_request_duration_seconds")).To(Equal(1))
+		Expect(testutil.GatherAndCount(exporter.Registry(), "tap_incident_stream_duration_seconds")).To(Equal(1))
+		Expect(testutil.GatherAndCount(exporter.Registry(), "tap_incident_output_bytes_total")).To(Equal(2))
+	})
+
+	When("rate limited", func() {
+		BeforeEach(func() {
+			opts = append(opts, fakeapi.WithRateLimit(fakeapi.RateLimit{Requests: 2, Window: time.Minute}))
+		})
+
+		It("counts the 429s of every retry and the failed sync", func() {
+			Expect(sync()).NotTo(Succeed())
+
+			Expect(testutil.GatherAndCompare(exporter.Registry(), strings.NewReader(`
+# HELP tap_incident_api_rate_limited_total API requests rejected with a 429 as we'd exceeded the rate limit.
+# TYPE tap_incident_api_rate_limited_total counter
+tap_incident_api_rate_limited_total{endpoint="/v2/users"} 4
+# HELP tap_incident_last_sync_success Whether the last sync succeeded (1) or failed (0).
+# TYPE tap_incident_last_sync_success gauge
+tap_incident_last_sync_success 0
+# HELP tap_incident_last_sync_timestamp_seconds When the last sync finished, as a Unix timestamp.
+# TYPE tap_incident_last_sync_timestamp_seconds gauge
+tap_incident_last_sync_timestamp_seconds 1.7e+09
+`), "tap_incident_api_rate_limited_total", "tap_incident_last_sync_success", "tap_incident_last_sync_timestamp_seconds")).To(Succeed())
+		})
+	})
+
+	When("a request is rate limited once", func() {
+		BeforeEach(func() {
+			opts = append(opts, fakeapi.WithFault(fakeapi.Fault{Path: "/v2/users", Status: http.StatusTooManyRequests, Skip: 1, Times: 1}))
+		})
+
+		It("retries it, counting the 429 and the retry", func() {
+			Expect(sync()).To(Succeed())
+
+			Expect(testutil.GatherAndCompare(exporter.Registry(), strings.NewReader(`
+# HELP tap_incident_api_rate_limited_total API requests rejected with a 429 as we'd exceeded the rate limit.
+# TYPE tap_incident_api_rate_limited_total counter
+tap_incident_api_rate_limited_total{endpoint="/v2/users"} 1
+# HELP tap_incident_api_retries_total Attempts at API requests that retried an earlier attempt.
+# TYPE tap_incident_api_retries_total counter
+tap_incident_api_retries_total{endpoint="/v2/users"} 1
+# HELP tap_incident_records_total Records output for each stream.
+# TYPE tap_incident_records_total counter
+tap_incident_records_total{stream="users"} 60
+`), "tap_incident_api_rate_limited_total", "tap_incident_api_retries_total", "tap_incident_records_total")).To(Succeed())
+		})
+	})
+})
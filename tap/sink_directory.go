@@ -0,0 +1,266 @@
+package tap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/incident-io/singer-tap/model"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// FileFormat is the format DirectorySink writes each stream in.
+type FileFormat string
+
+var (
+	FileFormatNDJSON FileFormat = "ndjson"
+	FileFormatCSV    FileFormat = "csv"
+)
+
+// SchemaFileName is the sidecar DirectorySink writes describing every stream.
+const SchemaFileName = "_schema.json"
+
+type DirectorySinkOptions struct {
+	Format FileFormat
+	// NestedAsJSON writes nested objects to a single CSV column as JSON, rather than
+	// flattening their properties into columns such as "severity__name".
+	NestedAsJSON bool
+}
+
+// DirectorySink writes each stream to its own file in a directory, for when we want the
+// data on disk without running a Singer target. Only SCHEMA and RECORD messages are
+// written, so versions and state are ignored.
+type DirectorySink struct {
+	dir     string
+	opts    DirectorySinkOptions
+	files   map[string]*streamFile
+	schemas map[string]streamSchema
+}
+
+// streamSchema is what we write to the sidecar for each stream.
+type streamSchema struct {
+	File          string        `json:"file"`
+	Schema        *model.Schema `json:"schema"`
+	KeyProperties []string      `json:"key_properties"`
+	// Columns are the CSV columns, in order.
+	Columns []string `json:"columns,omitempty"`
+}
+
+type streamFile struct {
+	file    *os.File
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	columns []column
+}
+
+// column is a CSV column, holding the value at path within the record.
+type column struct {
+	name string
+	path []string
+}
+
+func NewDirectorySink(dir string, opts DirectorySinkOptions) (*DirectorySink, error) {
+	if opts.Format == "" {
+		opts.Format = FileFormatNDJSON
+	}
+	if opts.Format != FileFormatNDJSON && opts.Format != FileFormatCSV {
+		return nil, fmt.Errorf("unsupported file format: %s", opts.Format)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "creating output directory")
+	}
+
+	return &DirectorySink{
+		dir:     dir,
+		opts:    opts,
+		files:   map[string]*streamFile{},
+		schemas: map[string]streamSchema{},
+	}, nil
+}
+
+func (s *DirectorySink) Write(op *Output) error {
+	switch op.Type {
+	case OutputTypeSchema:
+		return s.open(op)
+	case OutputTypeRecord:
+		return s.write(op)
+	case OutputTypeBatch:
+		return errors.New("batches can't be written to an output directory")
+	default:
+		return nil
+	}
+}
+
+// Close flushes every file and writes the schema sidecar.
+func (s *DirectorySink) Close() error {
+	for stream, file := range s.files {
+		if file.csv != nil {
+			file.csv.Flush()
+			if err := file.csv.Error(); err != nil {
+				return errors.Wrapf(err, "writing %s", stream)
+			}
+		}
+		if err := file.buf.Flush(); err != nil {
+			return errors.Wrapf(err, "writing %s", stream)
+		}
+		if err := file.file.Close(); err != nil {
+			return errors.Wrapf(err, "closing %s", stream)
+		}
+	}
+	s.files = map[string]*streamFile{}
+
+	data, err := json.MarshalIndent(s.schemas, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(os.WriteFile(filepath.Join(s.dir, SchemaFileName), append(data, '\n'), 0o644), "writing schema")
+}
+
+func (s *DirectorySink) open(op *Output) error {
+	if _, ok := s.files[op.Stream]; ok {
+		return fmt.Errorf("already received the schema for stream %s", op.Stream)
+	}
+
+	name := fmt.Sprintf("%s.%s", op.Stream, s.opts.Format)
+	file, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return errors.Wrapf(err, "creating file for %s", op.Stream)
+	}
+
+	sf := &streamFile{file: file, buf: bufio.NewWriter(file)}
+	schema := streamSchema{File: name, Schema: op.Schema, KeyProperties: op.KeyProperties}
+
+	if s.opts.Format == FileFormatCSV {
+		sf.csv = csv.NewWriter(sf.buf)
+		sf.columns = s.columns(op.Schema.Properties, op.KeyProperties)
+		schema.Columns = lo.Map(sf.columns, func(c column, _ int) string { return c.name })
+
+		if err := sf.csv.Write(schema.Columns); err != nil {
+			return errors.Wrapf(err, "writing header for %s", op.Stream)
+		}
+	}
+
+	s.files[op.Stream] = sf
+	s.schemas[op.Stream] = schema
+
+	return nil
+}
+
+func (s *DirectorySink) write(op *Output) error {
+	file, ok := s.files[op.Stream]
+	if !ok {
+		return fmt.Errorf("received a record before the schema for stream %s", op.Stream)
+	}
+
+	data, err := json.Marshal(op.Record)
+	if err != nil {
+		return err
+	}
+
+	if file.csv == nil {
+		_, err := file.buf.Write(append(data, '\n'))
+		return err
+	}
+
+	// Read the record back from JSON so values are formatted as the target would see
+	// them, e.g. times in RFC3339.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var record map[string]any
+	if err := decoder.Decode(&record); err != nil {
+		return err
+	}
+
+	row := make([]string, len(file.columns))
+	for idx, column := range file.columns {
+		row[idx], err = cell(lookup(record, column.path))
+		if err != nil {
+			return errors.Wrapf(err, "writing %s", column.name)
+		}
+	}
+
+	return file.csv.Write(row)
+}
+
+// columns returns a column for each property, starting with the key properties and then
+// in name order. Unless we're writing nested objects as JSON, objects with declared
+// properties are flattened into a column per property.
+func (s *DirectorySink) columns(properties map[string]model.Property, keyProperties []string) []column {
+	var flatten func(prefix []string, properties map[string]model.Property) []column
+	flatten = func(prefix []string, properties map[string]model.Property) []column {
+		columns := []column{}
+		for _, name := range lo.Keys(properties) {
+			path := append(slices.Clone(prefix), name)
+			if property := properties[name]; len(property.Properties) > 0 && !s.opts.NestedAsJSON {
+				columns = append(columns, flatten(path, property.Properties)...)
+			} else {
+				columns = append(columns, column{name: strings.Join(path, "__"), path: path})
+			}
+		}
+
+		return columns
+	}
+
+	rank := func(c column) int {
+		if idx := lo.IndexOf(keyProperties, c.path[0]); idx >= 0 {
+			return idx
+		}
+
+		return len(keyProperties)
+	}
+
+	columns := flatten(nil, properties)
+	sort.Slice(columns, func(i, j int) bool {
+		if rank(columns[i]) != rank(columns[j]) {
+			return rank(columns[i]) < rank(columns[j])
+		}
+
+		return columns[i].name < columns[j].name
+	})
+
+	return columns
+}
+
+// lookup returns the value at path in the record, or nil if any parent is missing.
+func lookup(record map[string]any, path []string) any {
+	var value any = record
+	for _, name := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+
+	return value
+}
+
+// cell formats a decoded JSON value as a CSV cell, where anything that isn't a scalar is
+// written as JSON.
+func cell(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case json.Number:
+		return value.String(), nil
+	default:
+		data, err := json.Marshal(value)
+		return string(data), err
+	}
+}
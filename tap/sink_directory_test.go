@@ -0,0 +1,135 @@
+package tap_test
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirectorySink", func() {
+	var (
+		fake *fakeTap
+		dir  string
+		opts tap.DirectorySinkOptions
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		opts = tap.DirectorySinkOptions{}
+
+		fake = newFakeTap(fakeapi.Generate(1, fakeapi.Size{Users: 5}), map[string]tap.Stream{"users": &tap.StreamUsers{}})
+
+		// Deselect the users' email, which should be left out of the files.
+		for idx, metadata := range *fake.Catalog.Streams[0].Metadata {
+			if len(metadata.Breadcrumb) > 0 && metadata.Breadcrumb[len(metadata.Breadcrumb)-1] == "email" {
+				(*fake.Catalog.Streams[0].Metadata)[idx].Metadata.Selected = lo.ToPtr(false)
+			}
+		}
+	})
+
+	JustBeforeEach(func() {
+		sink, err := tap.NewDirectorySink(dir, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		ol := tap.NewSinkOutputLogger(sink)
+		Expect(fake.Sync(ol, nil, config.Config{})).To(Succeed())
+		Expect(ol.Close()).To(Succeed())
+	})
+
+	readCSV := func() [][]string {
+		file, err := os.Open(filepath.Join(dir, "users.csv"))
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+
+		rows, err := csv.NewReader(file).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+
+		return rows
+	}
+
+	It("writes a sidecar describing each stream", func() {
+		data, err := os.ReadFile(filepath.Join(dir, tap.SchemaFileName))
+		Expect(err).NotTo(HaveOccurred())
+
+		var schemas map[string]struct {
+			File          string   `json:"file"`
+			KeyProperties []string `json:"key_properties"`
+			Schema        struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"schema"`
+		}
+		Expect(json.Unmarshal(data, &schemas)).To(Succeed())
+
+		Expect(schemas).To(HaveKey("users"))
+		Expect(schemas["users"].File).To(Equal("users.ndjson"))
+		Expect(schemas["users"].KeyProperties).To(Equal([]string{"id"}))
+		Expect(schemas["users"].Schema.Properties).To(HaveKey("name"))
+		Expect(schemas["users"].Schema.Properties).NotTo(HaveKey("email"))
+	})
+
+	It("writes a line per record as NDJSON", func() {
+		file, err := os.Open(filepath.Join(dir, "users.ndjson"))
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+
+		records := []map[string]any{}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var record map[string]any
+			Expect(json.Unmarshal(scanner.Bytes(), &record)).To(Succeed())
+			records = append(records, record)
+		}
+
+		Expect(records).To(HaveLen(5))
+		Expect(records).To(HaveEach(SatisfyAll(HaveKey("base_role"), Not(HaveKey("email")))))
+	})
+
+	Context("as CSV", func() {
+		BeforeEach(func() {
+			opts.Format = tap.FileFormatCSV
+		})
+
+		It("flattens nested objects into columns, with the key first", func() {
+			rows := readCSV()
+			Expect(rows).To(HaveLen(6))
+			Expect(rows[0]).To(Equal([]string{
+				"id",
+				"base_role__description",
+				"base_role__id",
+				"base_role__name",
+				"base_role__slug",
+				"custom_roles",
+				"name",
+				"slack_user_id",
+			}))
+
+			Expect(rows[1][3]).NotTo(BeEmpty())
+			Expect(rows[1][5]).To(HavePrefix("["))
+		})
+
+		Context("with nested objects as JSON", func() {
+			BeforeEach(func() {
+				opts.NestedAsJSON = true
+			})
+
+			It("writes them to a single column", func() {
+				rows := readCSV()
+				Expect(rows[0]).To(Equal([]string{"id", "base_role", "custom_roles", "name", "slack_user_id"}))
+
+				var role map[string]any
+				Expect(json.Unmarshal([]byte(rows[1][1]), &role)).To(Succeed())
+				Expect(role).To(HaveKey("slug"))
+			})
+		})
+	})
+})
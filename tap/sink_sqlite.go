@@ -0,0 +1,424 @@
+package tap
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/incident-io/singer-tap/model"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	_ "modernc.org/sqlite"
+)
+
+// Columns we add to tables alongside the stream's properties.
+const (
+	// SQLiteVersionColumn holds the table version of full-table streams, so rows from
+	// older versions can be removed when a new version is activated.
+	SQLiteVersionColumn = "_sdc_table_version"
+	// SQLiteIndexColumn is the position of a row in the array it came from, in child
+	// tables.
+	SQLiteIndexColumn = "_sdc_index"
+	// SQLiteParentPrefix prefixes the key properties of the parent record in child
+	// tables, e.g. "_sdc_parent_id".
+	SQLiteParentPrefix = "_sdc_parent_"
+)
+
+// SQLiteSink writes each stream to a table in a SQLite database, upserting records on
+// the stream's key properties so the same file can be synced into repeatedly.
+//
+// Nested objects and arrays are stored as JSON, which SQLite's JSON functions can query.
+// Arrays of objects are also written to a child table named after the stream and
+// property, e.g. "incidents__incident_role_assignments", with a row per element.
+type SQLiteSink struct {
+	db     *sql.DB
+	tx     *sql.Tx
+	tables map[string]*sqliteTable
+}
+
+type sqliteTable struct {
+	name          string
+	keyProperties []string
+	columns       map[string]string // name to column type
+	children      map[string]*sqliteTable
+	statements    map[string]*sql.Stmt
+}
+
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "starting transaction")
+	}
+
+	return &SQLiteSink{db: db, tx: tx, tables: map[string]*sqliteTable{}}, nil
+}
+
+func (s *SQLiteSink) Write(op *Output) error {
+	switch op.Type {
+	case OutputTypeSchema:
+		return s.createTable(op)
+	case OutputTypeRecord:
+		return s.upsert(op)
+	case OutputTypeActivateVersion:
+		return s.activateVersion(op)
+	case OutputTypeBatch:
+		return errors.New("batches can't be written to a SQLite database")
+	default:
+		return nil
+	}
+}
+
+// Close commits everything we've written. Nothing is written if we fail before then.
+func (s *SQLiteSink) Close() error {
+	if err := s.tx.Commit(); err != nil {
+		return errors.Wrap(err, "committing")
+	}
+
+	return s.db.Close()
+}
+
+// createTable creates the table for a stream, and any child tables, adding columns for
+// new properties if they already exist.
+func (s *SQLiteSink) createTable(op *Output) error {
+	columns := lo.MapValues(op.Schema.Properties, func(property model.Property, _ string) string {
+		return columnType(property)
+	})
+
+	table, err := s.ensureTable(op.Stream, op.KeyProperties, columns)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(op.Schema.Properties) {
+		// Child tables are keyed by their parent and position, so we can only create them
+		// when the parent has a key.
+		property := op.Schema.Properties[name]
+		if len(op.KeyProperties) == 0 || property.Items == nil || len(property.Items.Properties) == 0 {
+			continue
+		}
+
+		childColumns := map[string]string{SQLiteIndexColumn: "INTEGER"}
+		childKeys := []string{}
+		for _, key := range op.KeyProperties {
+			childColumns[SQLiteParentPrefix+key] = columns[key]
+			childKeys = append(childKeys, SQLiteParentPrefix+key)
+		}
+		childKeys = append(childKeys, SQLiteIndexColumn)
+		for itemName, itemProperty := range property.Items.Properties {
+			childColumns[itemName] = columnType(itemProperty)
+		}
+
+		child, err := s.ensureTable(op.Stream+"__"+name, childKeys, childColumns)
+		if err != nil {
+			return err
+		}
+		table.children[name] = child
+	}
+
+	s.tables[op.Stream] = table
+
+	return nil
+}
+
+func (s *SQLiteSink) ensureTable(name string, keyProperties []string, columns map[string]string) (*sqliteTable, error) {
+	table := &sqliteTable{
+		name:          name,
+		keyProperties: keyProperties,
+		columns:       map[string]string{},
+		children:      map[string]*sqliteTable{},
+		statements:    map[string]*sql.Stmt{},
+	}
+
+	existing, err := s.existingColumns(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) == 0 {
+		definitions := lo.Map(sortedKeys(columns), func(column string, _ int) string {
+			return fmt.Sprintf("%s %s", quote(column), columns[column])
+		})
+		if len(keyProperties) > 0 {
+			definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(lo.Map(keyProperties, quoteEach), ", ")))
+		}
+
+		query := fmt.Sprintf("CREATE TABLE %s (%s)", quote(name), strings.Join(definitions, ", "))
+		if _, err := s.tx.Exec(query); err != nil {
+			return nil, errors.Wrapf(err, "creating table %s", name)
+		}
+
+		table.columns = columns
+		return table, nil
+	}
+
+	// The table already exists, so add any properties that are new since it was
+	// created. Columns for properties that have gone are left alone.
+	table.columns = existing
+	for _, column := range sortedKeys(columns) {
+		if err := table.ensureColumn(s.tx, column, columns[column]); err != nil {
+			return nil, err
+		}
+	}
+
+	return table, nil
+}
+
+func (s *SQLiteSink) existingColumns(table string) (map[string]string, error) {
+	rows, err := s.tx.Query(fmt.Sprintf("SELECT name, type FROM pragma_table_info(%s)", literal(table)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting table %s", table)
+	}
+	defer rows.Close()
+
+	columns := map[string]string{}
+	for rows.Next() {
+		var name, columnType string
+		if err := rows.Scan(&name, &columnType); err != nil {
+			return nil, err
+		}
+		columns[name] = columnType
+	}
+
+	return columns, rows.Err()
+}
+
+func (s *SQLiteSink) upsert(op *Output) error {
+	table, ok := s.tables[op.Stream]
+	if !ok {
+		return fmt.Errorf("received a record before the schema for stream %s", op.Stream)
+	}
+
+	record, err := normalise(op.Record)
+	if err != nil {
+		return err
+	}
+
+	if op.Version != nil {
+		if err := table.ensureColumn(s.tx, SQLiteVersionColumn, "INTEGER"); err != nil {
+			return err
+		}
+		record[SQLiteVersionColumn] = *op.Version
+	}
+
+	if err := table.upsert(s.tx, record); err != nil {
+		return err
+	}
+
+	// Replace the rows of each child table for this record, if the record has the
+	// array. Records marking a deletion only carry the key, so leave the children alone.
+	for name, child := range table.children {
+		value, ok := record[name]
+		if !ok {
+			continue
+		}
+
+		parent := map[string]any{}
+		for _, key := range table.keyProperties {
+			parent[SQLiteParentPrefix+key] = record[key]
+		}
+
+		if err := child.delete(s.tx, parent); err != nil {
+			return err
+		}
+
+		elements, _ := value.([]any)
+		for idx, element := range elements {
+			object, ok := element.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			row := lo.Assign(object, parent, map[string]any{SQLiteIndexColumn: idx})
+			if err := child.upsert(s.tx, row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// activateVersion removes rows from earlier versions of a full-table stream, as they are
+// no longer present in incident.io.
+func (s *SQLiteSink) activateVersion(op *Output) error {
+	table, ok := s.tables[op.Stream]
+	if !ok || op.Version == nil {
+		return nil
+	}
+
+	if _, ok := table.columns[SQLiteVersionColumn]; !ok {
+		return nil
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s IS NULL OR %s < ?", quote(table.name), quote(SQLiteVersionColumn), quote(SQLiteVersionColumn))
+	if _, err := s.tx.Exec(query, *op.Version); err != nil {
+		return errors.Wrapf(err, "removing old versions from %s", table.name)
+	}
+
+	return nil
+}
+
+func (t *sqliteTable) ensureColumn(tx *sql.Tx, column, columnType string) error {
+	if _, ok := t.columns[column]; ok {
+		return nil
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", quote(t.name), quote(column), columnType)
+	if _, err := tx.Exec(query); err != nil {
+		return errors.Wrapf(err, "adding column %s to %s", column, t.name)
+	}
+
+	t.columns[column] = columnType
+	return nil
+}
+
+// upsert inserts the row, or updates the columns it has if a row with the same key
+// already exists. Values for columns the table doesn't have are ignored.
+func (t *sqliteTable) upsert(tx *sql.Tx, row map[string]any) error {
+	columns := lo.Filter(sortedKeys(row), func(column string, _ int) bool {
+		_, ok := t.columns[column]
+		return ok
+	})
+
+	signature := strings.Join(columns, ",")
+	stmt, ok := t.statements[signature]
+	if !ok {
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			quote(t.name),
+			strings.Join(lo.Map(columns, quoteEach), ", "),
+			strings.Join(lo.Map(columns, func(string, int) string { return "?" }), ", "),
+		)
+
+		if len(t.keyProperties) > 0 {
+			updates := lo.FilterMap(columns, func(column string, _ int) (string, bool) {
+				return fmt.Sprintf("%s = excluded.%s", quote(column), quote(column)), !lo.Contains(t.keyProperties, column)
+			})
+
+			if len(updates) == 0 {
+				query += fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(lo.Map(t.keyProperties, quoteEach), ", "))
+			} else {
+				query += fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(lo.Map(t.keyProperties, quoteEach), ", "), strings.Join(updates, ", "))
+			}
+		}
+
+		var err error
+		stmt, err = tx.Prepare(query)
+		if err != nil {
+			return errors.Wrapf(err, "preparing insert into %s", t.name)
+		}
+		t.statements[signature] = stmt
+	}
+
+	args := make([]any, len(columns))
+	for idx, column := range columns {
+		value, err := columnValue(row[column])
+		if err != nil {
+			return errors.Wrapf(err, "encoding %s", column)
+		}
+		args[idx] = value
+	}
+
+	if _, err := stmt.Exec(args...); err != nil {
+		return errors.Wrapf(err, "writing to %s", t.name)
+	}
+
+	return nil
+}
+
+func (t *sqliteTable) delete(tx *sql.Tx, where map[string]any) error {
+	columns := sortedKeys(where)
+	conditions := lo.Map(columns, func(column string, _ int) string {
+		return fmt.Sprintf("%s = ?", quote(column))
+	})
+	args := lo.Map(columns, func(column string, _ int) any { return where[column] })
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", quote(t.name), strings.Join(conditions, " AND "))
+	if _, err := tx.Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "deleting from %s", t.name)
+	}
+
+	return nil
+}
+
+// columnType picks the SQLite type for a property, where anything that isn't a scalar
+// is stored as JSON text.
+func columnType(property model.Property) string {
+	types := lo.Without(property.Types, "null")
+	if len(types) != 1 {
+		return "TEXT"
+	}
+
+	switch types[0] {
+	case "integer", "boolean":
+		return "INTEGER"
+	case "number":
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// normalise reads the record back from JSON, so values are stored as the target would
+// see them, e.g. times in RFC3339.
+func normalise(record map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var normalised map[string]any
+	if err := decoder.Decode(&normalised); err != nil {
+		return nil, err
+	}
+
+	return normalised, nil
+}
+
+func columnValue(value any) (any, error) {
+	switch value := value.(type) {
+	case nil, string, bool, int, int64:
+		return value, nil
+	case json.Number:
+		if integer, err := value.Int64(); err == nil {
+			return integer, nil
+		}
+		return value.Float64()
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := lo.Keys(values)
+	sort.Strings(keys)
+
+	return keys
+}
+
+func quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func quoteEach(identifier string, _ int) string {
+	return quote(identifier)
+}
+
+func literal(value string) string {
+	return `'` + strings.ReplaceAll(value, `'`, `''`) + `'`
+}
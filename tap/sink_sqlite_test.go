@@ -0,0 +1,111 @@
+package tap_test
+
+import (
+	"database/sql"
+	"path/filepath"
+
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SQLiteSink", func() {
+	var (
+		fake *fakeTap
+		data *fakeapi.Data
+		path string
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "incident.db")
+
+		data = fakeapi.Generate(1, fakeapi.Size{Incidents: 5, CustomFields: 3})
+		for idx := range data.Incidents {
+			data.Incidents[idx].Mode = client.IncidentV2ModeStandard
+		}
+		data.Incidents[0].IncidentRoleAssignments = []client.IncidentRoleAssignmentV2{
+			{
+				Role:     client.EmbeddedIncidentRoleV2{Id: "01ROLELEAD", Name: "Incident Lead"},
+				Assignee: &client.UserV2{Id: "01USERLISA", Name: "Lisa"},
+			},
+		}
+
+		fake = newFakeTap(data, map[string]tap.Stream{
+			"incidents":     &tap.StreamIncidents{},
+			"custom_fields": &tap.StreamCustomFields{},
+		})
+	})
+
+	sync := func(state *tap.State) {
+		sink, err := tap.NewSQLiteSink(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		ol := tap.NewSinkOutputLogger(sink)
+		Expect(fake.Sync(ol, state, config.Config{})).To(Succeed())
+		Expect(ol.Close()).To(Succeed())
+	}
+
+	query := func(query string, args ...any) []string {
+		db, err := sql.Open("sqlite", path)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+
+		rows, err := db.Query(query, args...)
+		Expect(err).NotTo(HaveOccurred())
+		defer rows.Close()
+
+		values := []string{}
+		for rows.Next() {
+			var value sql.NullString
+			Expect(rows.Scan(&value)).To(Succeed())
+			values = append(values, value.String)
+		}
+		Expect(rows.Err()).NotTo(HaveOccurred())
+
+		return values
+	}
+
+	It("creates a table per stream, storing nested objects as JSON", func() {
+		sync(nil)
+
+		Expect(query(`SELECT id FROM incidents ORDER BY id`)).To(HaveLen(5))
+		Expect(query(`SELECT json_extract(severity, '$.name') FROM incidents WHERE id = ?`, data.Incidents[0].Id)).To(
+			Equal([]string{data.Incidents[0].Severity.Name}))
+	})
+
+	It("creates child tables for arrays of objects", func() {
+		sync(nil)
+
+		Expect(query(`SELECT json_extract(assignee, '$.name') FROM incidents__incident_role_assignments WHERE _sdc_parent_id = ?`, data.Incidents[0].Id)).To(
+			Equal([]string{"Lisa"}))
+		Expect(query(`SELECT _sdc_parent_id FROM incidents__incident_timestamp_values`)).To(HaveLen(5))
+	})
+
+	It("upserts on the key when synced again", func() {
+		sync(nil)
+
+		data.Incidents[0].Name = "Renamed"
+		data.Incidents[0].IncidentRoleAssignments = nil
+		sync(nil)
+
+		Expect(query(`SELECT id FROM incidents`)).To(HaveLen(5))
+		Expect(query(`SELECT name FROM incidents WHERE id = ?`, data.Incidents[0].Id)).To(Equal([]string{"Renamed"}))
+		Expect(query(`SELECT _sdc_parent_id FROM incidents__incident_role_assignments`)).To(BeEmpty())
+	})
+
+	It("removes rows missing from the latest version of full-table streams", func() {
+		sync(nil)
+		Expect(query(`SELECT id FROM custom_fields`)).To(HaveLen(3))
+
+		deleted := data.CustomFields[0]
+		data.CustomFields = data.CustomFields[1:]
+		sync(&tap.State{Bookmarks: map[string]tap.Bookmark{"custom_fields": {Version: 1}}})
+
+		Expect(query(`SELECT id FROM custom_fields`)).To(HaveLen(2))
+		Expect(query(`SELECT id FROM custom_fields WHERE id = ?`, deleted.Id)).To(BeEmpty())
+	})
+})
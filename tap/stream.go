@@ -6,10 +6,14 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 )
 
 var streams = map[string]Stream{}
 
+// optionalStreams are only synced when selected in the catalog, rather than by default.
+var optionalStreams = map[string]bool{}
+
 func register(s Stream) {
 	op := s.Output()
 	if _, ok := streams[op.Stream]; ok {
@@ -19,6 +23,13 @@ func register(s Stream) {
 	streams[op.Stream] = s
 }
 
+// registerOptional registers a stream that isn't selected by default, such as those
+// derived from another stream's records.
+func registerOptional(s Stream) {
+	register(s)
+	optionalStreams[s.Output().Stream] = true
+}
+
 // Stream is a data model from the incident.io API that we want to represent as a Singer
 // tap stream.
 type Stream interface {
@@ -26,5 +37,7 @@ type Stream interface {
 	Output() *Output
 	// GetRecords returns a slice of entries in the stream. People will eventually ask for
 	// this to be a channel, but we're going simple and loading everything for now.
-	GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error)
+	//
+	// The config is passed so streams can apply their stream specific settings.
+	GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error)
 }
@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -30,19 +32,24 @@ func (s *StreamActions) Output() *Output {
 	}
 }
 
-func (s *StreamActions) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.ActionsV2ListWithResponse(ctx, &client.ActionsV2ListParams{})
+func (s *StreamActions) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.ActionV2]{
+		Endpoint: Endpoint{Name: "actions"},
+		Options:  cfg.Stream("actions"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.ActionV2], error) {
+			response, err := cl.ActionsV2ListWithResponse(ctx, &client.ActionsV2ListParams{})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing actions")
+			}
+
+			return &Page[client.ActionV2]{Results: response.JSON200.Actions}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing actions")
-	}
-
-	for _, element := range response.JSON200.Actions {
-		results = append(results, model.ActionV2.Serialize(element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.ActionV2, _ int) map[string]any {
+		return model.ActionV2.Serialize(element)
+	}), nil
 }
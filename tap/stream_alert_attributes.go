@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -29,20 +31,24 @@ func (s *StreamAlertAttributes) Output() *Output {
 	}
 }
 
-func (s *StreamAlertAttributes) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	logger.Log("msg", "loading alert attributes")
-	page, err := cl.AlertAttributesV2ListWithResponse(ctx)
+func (s *StreamAlertAttributes) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.AlertAttributeV2]{
+		Endpoint: Endpoint{Name: "alert_attributes"},
+		Options:  cfg.Stream("alert_attributes"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.AlertAttributeV2], error) {
+			response, err := cl.AlertAttributesV2ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing alert attributes")
+			}
+
+			return &Page[client.AlertAttributeV2]{Results: response.JSON200.AlertAttributes}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing alert attributes")
-	}
-
-	for _, element := range page.JSON200.AlertAttributes {
-		results = append(results, model.AlertAttributeV2.Serialize(element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.AlertAttributeV2, _ int) map[string]any {
+		return model.AlertAttributeV2.Serialize(element)
+	}), nil
 }
@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -29,20 +31,24 @@ func (s *StreamAlertSources) Output() *Output {
 	}
 }
 
-func (s *StreamAlertSources) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	logger.Log("msg", "loading alert sources")
-	page, err := cl.AlertSourcesV2ListWithResponse(ctx)
+func (s *StreamAlertSources) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.AlertSourceV2]{
+		Endpoint: Endpoint{Name: "alert_sources"},
+		Options:  cfg.Stream("alert_sources"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.AlertSourceV2], error) {
+			response, err := cl.AlertSourcesV2ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing alert sources")
+			}
+
+			return &Page[client.AlertSourceV2]{Results: response.JSON200.AlertSources}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing alert sources")
+		return nil, err
 	}
 
-	for _, element := range page.JSON200.AlertSources {
-		results = append(results, model.AlertSourceV2.Serialize(element))
-	}
-
-	return results, nil
-}
\ No newline at end of file
+	return lo.Map(elements, func(element client.AlertSourceV2, _ int) map[string]any {
+		return model.AlertSourceV2.Serialize(element)
+	}), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
@@ -30,30 +31,30 @@ func (s *StreamAlerts) Output() *Output {
 	}
 }
 
-func (s *StreamAlerts) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		after    *string
-		pageSize = int64(50)
-		results  = []map[string]any{}
-	)
-
-	for {
-		logger.Log("msg", "loading alerts page", "page_size", pageSize, "after", after)
-		page, err := cl.AlertsV2ListWithResponse(ctx, &client.AlertsV2ListParams{
-			PageSize: pageSize,
-			After:    after,
-		})
-		if err != nil {
-			return nil, errors.Wrap(err, "listing alerts")
-		}
-
-		for _, element := range page.JSON200.Alerts {
-			results = append(results, model.AlertV2.Serialize(element))
-		}
-		if count := len(page.JSON200.Alerts); count == 0 {
-			return results, nil // end pagination
-		} else {
-			after = lo.ToPtr(page.JSON200.Alerts[count-1].Id)
-		}
+func (s *StreamAlerts) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	alerts, err := Paginator[client.AlertV2]{
+		Endpoint: Endpoint{Name: "alerts", MaxPageSize: config.MaxPageSizes["alerts"]},
+		Options:  cfg.Stream("alerts"),
+		Fetch: func(ctx context.Context, req PageRequest) (*Page[client.AlertV2], error) {
+			page, err := cl.AlertsV2ListWithResponse(ctx, &client.AlertsV2ListParams{
+				PageSize: req.PageSize,
+				After:    req.After,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing alerts")
+			}
+
+			return &Page[client.AlertV2]{
+				Results:        page.JSON200.Alerts,
+				PaginationMeta: &PaginationMeta{After: page.JSON200.PaginationMeta.After},
+			}, nil
+		},
+	}.All(ctx, logger)
+	if err != nil {
+		return nil, err
 	}
+
+	return lo.Map(alerts, func(alert client.AlertV2, _ int) map[string]any {
+		return model.AlertV2.Serialize(alert)
+	}), nil
 }
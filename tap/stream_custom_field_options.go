@@ -5,9 +5,9 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
-	"github.com/samber/lo"
 )
 
 func init() {
@@ -31,25 +31,27 @@ func (s *StreamCustomFieldOptions) Output() *Output {
 	}
 }
 
-func (s *StreamCustomFieldOptions) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
+func (s *StreamCustomFieldOptions) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
 	var (
 		results = []map[string]any{}
 	)
 
+	opts := cfg.Stream("custom_field_options")
+
 	// We need to go over all custom fields to build the options
-	response, err := cl.CustomFieldsV2ListWithResponse(ctx)
+	customFields, err := listCustomFields(ctx, logger, cl, childOptions(opts))
 	if err != nil {
-		return nil, errors.Wrap(err, "listing incidents")
+		return nil, err
 	}
 
-	for _, element := range response.JSON200.CustomFields {
-		options, err := s.GetOptions(ctx, logger, cl, element.Id)
+	for _, element := range customFields {
+		options, err := s.GetOptions(ctx, logger, cl, opts, element.Id)
 		if err != nil {
 			return nil, errors.Wrap(err, "listing custom field options")
 		}
 
 		for _, option := range options {
-			results = append(results, model.CustomFieldOptionV1.Serialize(&option))
+			results = append(results, model.CustomFieldOptionV1.Serialize(option))
 		}
 	}
 
@@ -60,30 +62,26 @@ func (s *StreamCustomFieldOptions) GetOptions(
 	ctx context.Context,
 	logger kitlog.Logger,
 	cl *client.ClientWithResponses,
+	opts config.StreamConfig,
 	customFieldId string,
 ) ([]client.CustomFieldOptionV1, error) {
-	var (
-		after    *string
-		pageSize = int64(250)
-		results  = []client.CustomFieldOptionV1{}
-	)
+	return Paginator[client.CustomFieldOptionV1]{
+		Endpoint: Endpoint{Name: "custom_field_options", MaxPageSize: config.MaxPageSizes["custom_field_options"]},
+		Options:  opts,
+		Fetch: func(ctx context.Context, req PageRequest) (*Page[client.CustomFieldOptionV1], error) {
+			page, err := cl.CustomFieldOptionsV1ListWithResponse(ctx, &client.CustomFieldOptionsV1ListParams{
+				CustomFieldId: customFieldId,
+				PageSize:      &req.PageSize,
+				After:         req.After,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing custom field options")
+			}
 
-	for {
-		page, err := cl.CustomFieldOptionsV1ListWithResponse(ctx, &client.CustomFieldOptionsV1ListParams{
-			CustomFieldId: customFieldId,
-			PageSize:      &pageSize,
-			After:         after,
-		})
-		if err != nil {
-			return nil, errors.Wrap(err, "listing custom field options")
-		}
-
-		results = append(results, page.JSON200.CustomFieldOptions...)
-
-		if count := len(page.JSON200.CustomFieldOptions); count == 0 {
-			return results, nil // end pagination
-		} else {
-			after = lo.ToPtr(page.JSON200.CustomFieldOptions[count-1].Id)
-		}
-	}
+			return &Page[client.CustomFieldOptionV1]{
+				Results:        page.JSON200.CustomFieldOptions,
+				PaginationMeta: &PaginationMeta{After: page.JSON200.PaginationMeta.After},
+			}, nil
+		},
+	}.All(ctx, logger)
 }
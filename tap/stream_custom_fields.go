@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -30,19 +32,30 @@ func (s *StreamCustomFields) Output() *Output {
 	}
 }
 
-func (s *StreamCustomFields) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.CustomFieldsV2ListWithResponse(ctx)
+func (s *StreamCustomFields) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	customFields, err := listCustomFields(ctx, logger, cl, cfg.Stream("custom_fields"))
 	if err != nil {
-		return nil, errors.Wrap(err, "listing custom fields")
+		return nil, err
 	}
 
-	for _, element := range response.JSON200.CustomFields {
-		results = append(results, model.CustomFieldV2.Serialize(element))
-	}
+	return lo.Map(customFields, func(element client.CustomFieldV2, _ int) map[string]any {
+		return model.CustomFieldV2.Serialize(element)
+	}), nil
+}
 
-	return results, nil
+// listCustomFields loads every custom field, which is also needed to find the options
+// of each field.
+func listCustomFields(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig) ([]client.CustomFieldV2, error) {
+	return Paginator[client.CustomFieldV2]{
+		Endpoint: Endpoint{Name: "custom_fields"},
+		Options:  opts,
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.CustomFieldV2], error) {
+			response, err := cl.CustomFieldsV2ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing custom fields")
+			}
+
+			return &Page[client.CustomFieldV2]{Results: response.JSON200.CustomFields}, nil
+		},
+	}.All(ctx, logger)
 }
@@ -5,6 +5,7 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
@@ -31,38 +32,30 @@ func (s *StreamEscalations) Output() *Output {
 	}
 }
 
-func (s *StreamEscalations) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-		after   *string
-	)
-
-	for {
-		params := &client.EscalationsV2ListParams{
-			PageSize: lo.ToPtr(int64(50)), // Max page size
-			After:    after,
-		}
-
-		response, err := cl.EscalationsV2ListWithResponse(ctx, params)
-		if err != nil {
-			return nil, errors.Wrap(err, "listing escalations")
-		}
-
-		if response.StatusCode() != 200 {
-			return nil, errors.Errorf("unexpected status code: %d", response.StatusCode())
-		}
-
-		for _, element := range response.JSON200.Escalations {
-			results = append(results, model.EscalationV2.Serialize(element))
-		}
-
-		// Check if there are more pages
-		if response.JSON200.PaginationMeta.After == nil {
-			break
-		}
-
-		after = response.JSON200.PaginationMeta.After
+func (s *StreamEscalations) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	escalations, err := Paginator[client.EscalationV2]{
+		Endpoint: Endpoint{Name: "escalations", MaxPageSize: config.MaxPageSizes["escalations"]},
+		Options:  cfg.Stream("escalations"),
+		Fetch: func(ctx context.Context, req PageRequest) (*Page[client.EscalationV2], error) {
+			response, err := cl.EscalationsV2ListWithResponse(ctx, &client.EscalationsV2ListParams{
+				PageSize: &req.PageSize,
+				After:    req.After,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing escalations")
+			}
+
+			return &Page[client.EscalationV2]{
+				Results:        response.JSON200.Escalations,
+				PaginationMeta: &PaginationMeta{After: response.JSON200.PaginationMeta.After},
+			}, nil
+		},
+	}.All(ctx, logger)
+	if err != nil {
+		return nil, err
 	}
 
-	return results, nil
-}
\ No newline at end of file
+	return lo.Map(escalations, func(escalation client.EscalationV2, _ int) map[string]any {
+		return model.EscalationV2.Serialize(escalation)
+	}), nil
+}
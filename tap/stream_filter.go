@@ -5,12 +5,18 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 )
 
 type Filter struct {
 	Stream       Stream
 	CatalogEntry CatalogEntry
+	// Transformer, if set, applies transforms such as hashing personal data, before
+	// anything is flattened.
+	Transformer *Transformer
+	// Flattener, if set, unnests nested objects in both the schema and records.
+	Flattener *Flattener
 }
 
 func (s *Filter) Output() *Output {
@@ -18,12 +24,18 @@ func (s *Filter) Output() *Output {
 
 	// // We need to filter the schema based on the catalog entry we have
 	output.Schema.Properties = s.filterProperties(output.Schema.Properties, s.CatalogEntry)
+	if s.Transformer != nil {
+		output.Schema.Properties = s.Transformer.Schema(output.Schema.Properties)
+	}
+	if s.Flattener != nil {
+		output.Schema.Properties = s.Flattener.Schema(output.Schema.Properties)
+	}
 
 	return output
 }
 
-func (s *Filter) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	records, err := s.Stream.GetRecords(ctx, logger, cl)
+func (s *Filter) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	records, err := s.Stream.GetRecords(ctx, logger, cl, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +49,22 @@ func (s *Filter) GetRecords(ctx context.Context, logger kitlog.Logger, cl *clien
 		}
 	}
 
+	if s.Transformer != nil {
+		for _, record := range records {
+			s.Transformer.Record(record)
+		}
+	}
+
+	if s.Flattener != nil {
+		properties := s.Stream.Output().Schema.Properties
+		if s.Transformer != nil {
+			properties = s.Transformer.Schema(properties)
+		}
+		for idx, record := range records {
+			records[idx] = s.Flattener.Record(properties, record)
+		}
+	}
+
 	return records, nil
 }
 
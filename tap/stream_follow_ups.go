@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -23,25 +25,46 @@ func (s *StreamFollowUps) Output() *Output {
 		Schema: &model.Schema{
 			HasAdditionalProperties: false,
 			Type:                    []string{"object"},
-			Properties:              model.FollowUpV2.Schema().Properties,
+			Properties: lo.Assign(model.FollowUpV2.Schema().Properties, map[string]model.Property{
+				"incident_mode": {
+					Types: []string{"string"},
+				},
+			}),
 		},
 		KeyProperties:      []string{"id"},
 		BookmarkProperties: []string{},
 	}
 }
 
-func (s *StreamFollowUps) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
+func (s *StreamFollowUps) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	// The API only filters follow-ups by a single incident mode, so we load each mode in
+	// turn, which also tells us the mode of every follow-up's incident.
+	results := []map[string]any{}
+	for _, mode := range cfg.Modes() {
+		elements, err := Paginator[client.FollowUpV2]{
+			Endpoint: Endpoint{Name: "follow_ups"},
+			Options:  cfg.Stream("follow_ups"),
+			Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.FollowUpV2], error) {
+				response, err := cl.FollowUpsV2ListWithResponse(ctx, &client.FollowUpsV2ListParams{
+					IncidentMode: lo.ToPtr(client.FollowUpsV2ListParamsIncidentMode(mode)),
+				})
+				if err != nil {
+					return nil, errors.Wrap(err, "listing follow-ups")
+				}
 
-	response, err := cl.FollowUpsV2ListWithResponse(ctx, &client.FollowUpsV2ListParams{})
-	if err != nil {
-		return nil, errors.Wrap(err, "listing incident roles")
-	}
+				return &Page[client.FollowUpV2]{Results: response.JSON200.FollowUps}, nil
+			},
+		}.All(ctx, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, element := range elements {
+			record := model.FollowUpV2.Serialize(element)
+			record["incident_mode"] = mode
 
-	for _, element := range response.JSON200.FollowUps {
-		results = append(results, model.FollowUpV2.Serialize(element))
+			results = append(results, record)
+		}
 	}
 
 	return results, nil
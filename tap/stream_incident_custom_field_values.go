@@ -0,0 +1,65 @@
+package tap
+
+import (
+	"context"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+func init() {
+	registerOptional(&StreamIncidentCustomFieldValues{})
+}
+
+// StreamIncidentCustomFieldValues has a record per value of each custom field of each
+// incident, taken from the incidents we list for the incidents stream. Fields that allow
+// several values, such as multi-selects, have a record per value, identified by its
+// position.
+type StreamIncidentCustomFieldValues struct {
+}
+
+func (s *StreamIncidentCustomFieldValues) Output() *Output {
+	return &Output{
+		Type:   OutputTypeSchema,
+		Stream: "incident_custom_field_values",
+		Schema: &model.Schema{
+			HasAdditionalProperties: false,
+			Type:                    []string{"object"},
+			Properties: lo.Assign(model.CustomFieldValueV2.Schema().Properties, map[string]model.Property{
+				"incident_id":     {Types: []string{"string"}},
+				"custom_field_id": {Types: []string{"string"}},
+				"custom_field":    model.CustomFieldTypeInfoV2.Schema(),
+				"value_index":     {Types: []string{"integer"}},
+			}),
+		},
+		KeyProperties:      []string{"incident_id", "custom_field_id", "value_index"},
+		BookmarkProperties: []string{},
+	}
+}
+
+func (s *StreamIncidentCustomFieldValues) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	incidents, err := cachedIncidents(ctx, logger, cl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]any{}
+	for _, incident := range incidents {
+		for _, entry := range incident.CustomFieldEntries {
+			for idx, value := range entry.Values {
+				record := model.CustomFieldValueV2.Serialize(value)
+				record["incident_id"] = incident.Id
+				record["custom_field_id"] = entry.CustomField.Id
+				record["custom_field"] = model.CustomFieldTypeInfoV2.Serialize(entry.CustomField)
+				record["value_index"] = idx
+
+				results = append(results, record)
+			}
+		}
+	}
+
+	return results, nil
+}
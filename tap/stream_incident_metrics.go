@@ -0,0 +1,277 @@
+package tap
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+func init() {
+	registerOptional(&StreamIncidentMetrics{})
+}
+
+// StreamIncidentMetrics computes the lifecycle metrics of each incident, so every team
+// doesn't have to derive them from timestamps and updates themselves:
+//
+// - durations: between configured incident timestamps, e.g. time to resolve.
+// - status_category_seconds: how long the incident spent in each status category,
+// from its history of updates.
+// - severity_changes: every change of severity, from the same history.
+//
+// Incidents are shared with the incidents stream, and updates with the incident_updates
+// stream, so this doesn't make requests of its own when they are synced too.
+type StreamIncidentMetrics struct {
+}
+
+// statusCategories are the categories we report dwell times for.
+var statusCategories = []client.IncidentStatusV2Category{
+	client.IncidentStatusV2CategoryTriage,
+	client.IncidentStatusV2CategoryLive,
+	client.IncidentStatusV2CategoryPaused,
+	client.IncidentStatusV2CategoryLearning,
+	client.IncidentStatusV2CategoryClosed,
+	client.IncidentStatusV2CategoryDeclined,
+	client.IncidentStatusV2CategoryMerged,
+	client.IncidentStatusV2CategoryCanceled,
+	statusCategoryUnknown,
+}
+
+// statusCategoryUnknown is for the time between the incident being reported and its
+// first update, as updates don't say which status they changed from.
+const statusCategoryUnknown client.IncidentStatusV2Category = "unknown"
+
+// terminalStatusCategories end an incident, so time spent in them isn't counted.
+var terminalStatusCategories = []client.IncidentStatusV2Category{
+	client.IncidentStatusV2CategoryClosed,
+	client.IncidentStatusV2CategoryDeclined,
+	client.IncidentStatusV2CategoryMerged,
+	client.IncidentStatusV2CategoryCanceled,
+}
+
+func (s *StreamIncidentMetrics) Output() *Output {
+	integer := model.Property{Types: []string{"integer"}}
+
+	return &Output{
+		Type:   OutputTypeSchema,
+		Stream: "incident_metrics",
+		Schema: &model.Schema{
+			HasAdditionalProperties: false,
+			Type:                    []string{"object"},
+			Properties: map[string]model.Property{
+				"incident_id":   {Types: []string{"string"}},
+				"incident_mode": {Types: []string{"string"}},
+				"reported_at":   model.DateTime.Schema(),
+				"durations": model.ArrayOf(model.Property{
+					Types: []string{"object"},
+					Properties: map[string]model.Property{
+						"name":    {Types: []string{"string"}},
+						"from":    model.Optional(model.DateTime.Schema()),
+						"to":      model.Optional(model.DateTime.Schema()),
+						"seconds": model.Optional(integer),
+					},
+				}),
+				"status_category_seconds": {
+					Types: []string{"object"},
+					Properties: lo.SliceToMap(statusCategories, func(category client.IncidentStatusV2Category) (string, model.Property) {
+						return string(category), integer
+					}),
+				},
+				"initial_severity":      model.Optional(model.SeverityV2.Schema()),
+				"max_severity":          model.Optional(model.SeverityV2.Schema()),
+				"severity_change_count": integer,
+				"severity_changes": model.ArrayOf(model.Property{
+					Types: []string{"object"},
+					Properties: map[string]model.Property{
+						"changed_at": model.DateTime.Schema(),
+						"from":       model.Optional(model.SeverityV2.Schema()),
+						"to":         model.SeverityV2.Schema(),
+					},
+				}),
+			},
+		},
+		KeyProperties:      []string{"incident_id"},
+		BookmarkProperties: []string{},
+	}
+}
+
+func (s *StreamIncidentMetrics) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	incidents, err := cachedIncidents(ctx, logger, cl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	updates, err := cachedIncidentUpdates(ctx, logger, cl, childOptions(cfg.Stream("incident_metrics")))
+	if err != nil {
+		return nil, err
+	}
+	updatesByIncident := lo.GroupBy(updates, func(update client.IncidentUpdateV2) string {
+		return update.IncidentId
+	})
+
+	now := time.Now()
+	durations := cfg.Stream("incident_metrics").TimestampDurations()
+
+	return lo.Map(incidents, func(incident client.IncidentV2, _ int) map[string]any {
+		history := updatesByIncident[incident.Id]
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].CreatedAt.Before(history[j].CreatedAt)
+		})
+
+		record := map[string]any{
+			"incident_id":             incident.Id,
+			"incident_mode":           string(incident.Mode),
+			"reported_at":             incident.CreatedAt,
+			"durations":               timestampDurations(incident, durations),
+			"status_category_seconds": statusCategorySeconds(incident, history, now),
+		}
+
+		return lo.Assign(record, severityChanges(incident, history))
+	}), nil
+}
+
+// timestampDurations measures each duration between the incident's timestamps, where
+// the seconds are nil unless both timestamps have a value.
+func timestampDurations(incident client.IncidentV2, durations []config.TimestampDuration) []map[string]any {
+	values := map[string]*time.Time{}
+	if incident.IncidentTimestampValues != nil {
+		for _, timestamp := range *incident.IncidentTimestampValues {
+			var value *time.Time
+			if timestamp.Value != nil {
+				value = timestamp.Value.Value
+			}
+
+			values[timestamp.IncidentTimestamp.Id] = value
+			values[strings.ToLower(timestamp.IncidentTimestamp.Name)] = value
+		}
+	}
+
+	lookup := func(timestamp string) *time.Time {
+		if value, ok := values[timestamp]; ok {
+			return value
+		}
+
+		return values[strings.ToLower(timestamp)]
+	}
+
+	return lo.Map(durations, func(duration config.TimestampDuration, _ int) map[string]any {
+		from, to := lookup(duration.From), lookup(duration.To)
+
+		var seconds *int64
+		if from != nil && to != nil {
+			seconds = lo.ToPtr(int64(to.Sub(*from).Seconds()))
+		}
+
+		return map[string]any{
+			"name":    duration.Name,
+			"from":    from,
+			"to":      to,
+			"seconds": seconds,
+		}
+	})
+}
+
+// statusCategorySeconds adds up how long the incident spent in each status category,
+// where each update starts a period in its new status that lasts until the next. Any
+// time before the first update is unknown, and the current status counts until now,
+// unless it has ended the incident.
+func statusCategorySeconds(incident client.IncidentV2, history []client.IncidentUpdateV2, now time.Time) map[string]any {
+	seconds := lo.SliceToMap(statusCategories, func(category client.IncidentStatusV2Category) (string, any) {
+		return string(category), int64(0)
+	})
+
+	type period struct {
+		category client.IncidentStatusV2Category
+		start    time.Time
+	}
+
+	periods := lo.Map(history, func(update client.IncidentUpdateV2, _ int) period {
+		return period{category: update.NewIncidentStatus.Category, start: update.CreatedAt}
+	})
+	if len(periods) == 0 {
+		periods = []period{{category: incident.IncidentStatus.Category, start: incident.CreatedAt}}
+	} else if periods[0].start.After(incident.CreatedAt) {
+		periods = append([]period{{category: statusCategoryUnknown, start: incident.CreatedAt}}, periods...)
+	}
+
+	for idx, current := range periods {
+		end := now
+		if idx+1 < len(periods) {
+			end = periods[idx+1].start
+		} else if lo.Contains(terminalStatusCategories, current.category) {
+			continue
+		}
+
+		if _, ok := seconds[string(current.category)]; ok {
+			seconds[string(current.category)] = seconds[string(current.category)].(int64) + int64(end.Sub(current.start).Seconds())
+		}
+	}
+
+	return seconds
+}
+
+// severityChanges finds every change of severity in the incident's updates, along with
+// the severity it started with and the most severe it reached. The severity it started
+// with is only known if the first update with a severity was made as it was reported.
+func severityChanges(incident client.IncidentV2, history []client.IncidentUpdateV2) map[string]any {
+	var (
+		initial, current, highest *client.SeverityV2
+		changes                   = []map[string]any{}
+	)
+
+	serialize := func(severity *client.SeverityV2) map[string]any {
+		if severity == nil {
+			return nil
+		}
+
+		return model.SeverityV2.Serialize(*severity)
+	}
+
+	for _, update := range history {
+		if update.NewSeverity == nil {
+			continue
+		}
+
+		if current == nil {
+			current, highest = update.NewSeverity, update.NewSeverity
+			if !update.CreatedAt.After(incident.CreatedAt) {
+				initial = update.NewSeverity
+			}
+
+			continue
+		}
+
+		if update.NewSeverity.Id == current.Id {
+			continue
+		}
+
+		changes = append(changes, map[string]any{
+			"changed_at": update.CreatedAt,
+			"from":       serialize(current),
+			"to":         serialize(update.NewSeverity),
+		})
+
+		current = update.NewSeverity
+		if current.Rank > highest.Rank {
+			highest = current
+		}
+	}
+
+	// Without any history, all we know is the current severity.
+	if current == nil && incident.Severity != nil {
+		initial, highest = incident.Severity, incident.Severity
+	}
+
+	return map[string]any{
+		"initial_severity":      serialize(initial),
+		"max_severity":          serialize(highest),
+		"severity_change_count": len(changes),
+		"severity_changes":      changes,
+	}
+}
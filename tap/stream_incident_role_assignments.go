@@ -0,0 +1,57 @@
+package tap
+
+import (
+	"context"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+func init() {
+	registerOptional(&StreamIncidentRoleAssignments{})
+}
+
+// StreamIncidentRoleAssignments has a record per role of each incident, taken from the
+// incidents we list for the incidents stream.
+type StreamIncidentRoleAssignments struct {
+}
+
+func (s *StreamIncidentRoleAssignments) Output() *Output {
+	return &Output{
+		Type:   OutputTypeSchema,
+		Stream: "incident_role_assignments",
+		Schema: &model.Schema{
+			HasAdditionalProperties: false,
+			Type:                    []string{"object"},
+			Properties: lo.Assign(model.IncidentRoleAssignmentV2.Schema().Properties, map[string]model.Property{
+				"incident_id": {Types: []string{"string"}},
+				"role_id":     {Types: []string{"string"}},
+			}),
+		},
+		KeyProperties:      []string{"incident_id", "role_id"},
+		BookmarkProperties: []string{},
+	}
+}
+
+func (s *StreamIncidentRoleAssignments) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	incidents, err := cachedIncidents(ctx, logger, cl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]any{}
+	for _, incident := range incidents {
+		for _, assignment := range incident.IncidentRoleAssignments {
+			record := model.IncidentRoleAssignmentV2.Serialize(assignment)
+			record["incident_id"] = incident.Id
+			record["role_id"] = assignment.Role.Id
+
+			results = append(results, record)
+		}
+	}
+
+	return results, nil
+}
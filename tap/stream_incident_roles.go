@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -30,19 +32,24 @@ func (s *StreamIncidentRoles) Output() *Output {
 	}
 }
 
-func (s *StreamIncidentRoles) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.IncidentRolesV2ListWithResponse(ctx)
+func (s *StreamIncidentRoles) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.IncidentRoleV2]{
+		Endpoint: Endpoint{Name: "incident_roles"},
+		Options:  cfg.Stream("incident_roles"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.IncidentRoleV2], error) {
+			response, err := cl.IncidentRolesV2ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing incident roles")
+			}
+
+			return &Page[client.IncidentRoleV2]{Results: response.JSON200.IncidentRoles}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing incident roles")
-	}
-
-	for _, element := range response.JSON200.IncidentRoles {
-		results = append(results, model.IncidentRoleV2.Serialize(element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.IncidentRoleV2, _ int) map[string]any {
+		return model.IncidentRoleV2.Serialize(element)
+	}), nil
 }
@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -30,19 +32,24 @@ func (s *StreamIncidentStatuses) Output() *Output {
 	}
 }
 
-func (s *StreamIncidentStatuses) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.IncidentStatusesV1ListWithResponse(ctx)
+func (s *StreamIncidentStatuses) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.IncidentStatusV1]{
+		Endpoint: Endpoint{Name: "incident_statuses"},
+		Options:  cfg.Stream("incident_statuses"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.IncidentStatusV1], error) {
+			response, err := cl.IncidentStatusesV1ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing incident statuses")
+			}
+
+			return &Page[client.IncidentStatusV1]{Results: response.JSON200.IncidentStatuses}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing incident statuses")
-	}
-
-	for _, element := range response.JSON200.IncidentStatuses {
-		results = append(results, model.IncidentStatusV1.Serialize(element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.IncidentStatusV1, _ int) map[string]any {
+		return model.IncidentStatusV1.Serialize(element)
+	}), nil
 }
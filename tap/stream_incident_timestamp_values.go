@@ -0,0 +1,61 @@
+package tap
+
+import (
+	"context"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+func init() {
+	registerOptional(&StreamIncidentTimestampValues{})
+}
+
+// StreamIncidentTimestampValues has a record per timestamp of each incident, taken from
+// the incidents we list for the incidents stream.
+type StreamIncidentTimestampValues struct {
+}
+
+func (s *StreamIncidentTimestampValues) Output() *Output {
+	return &Output{
+		Type:   OutputTypeSchema,
+		Stream: "incident_timestamp_values",
+		Schema: &model.Schema{
+			HasAdditionalProperties: false,
+			Type:                    []string{"object"},
+			Properties: lo.Assign(model.IncidentTimestampWithValueV2.Schema().Properties, map[string]model.Property{
+				"incident_id":           {Types: []string{"string"}},
+				"incident_timestamp_id": {Types: []string{"string"}},
+			}),
+		},
+		KeyProperties:      []string{"incident_id", "incident_timestamp_id"},
+		BookmarkProperties: []string{},
+	}
+}
+
+func (s *StreamIncidentTimestampValues) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	incidents, err := cachedIncidents(ctx, logger, cl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]any{}
+	for _, incident := range incidents {
+		if incident.IncidentTimestampValues == nil {
+			continue
+		}
+
+		for _, timestamp := range *incident.IncidentTimestampValues {
+			record := model.IncidentTimestampWithValueV2.Serialize(timestamp)
+			record["incident_id"] = incident.Id
+			record["incident_timestamp_id"] = timestamp.IncidentTimestamp.Id
+
+			results = append(results, record)
+		}
+	}
+
+	return results, nil
+}
@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -30,19 +32,24 @@ func (s *StreamIncidentTimestamps) Output() *Output {
 	}
 }
 
-func (s *StreamIncidentTimestamps) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.IncidentTimestampsV2ListWithResponse(ctx)
+func (s *StreamIncidentTimestamps) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.IncidentTimestampV2]{
+		Endpoint: Endpoint{Name: "incident_timestamps"},
+		Options:  cfg.Stream("incident_timestamps"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.IncidentTimestampV2], error) {
+			response, err := cl.IncidentTimestampsV2ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing incident timestamps")
+			}
+
+			return &Page[client.IncidentTimestampV2]{Results: response.JSON200.IncidentTimestamps}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing incident timestamps")
-	}
-
-	for _, element := range response.JSON200.IncidentTimestamps {
-		results = append(results, model.IncidentTimestampV2.Serialize(element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.IncidentTimestampV2, _ int) map[string]any {
+		return model.IncidentTimestampV2.Serialize(element)
+	}), nil
 }
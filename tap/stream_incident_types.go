@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -23,26 +25,31 @@ func (s *StreamIncidentTypes) Output() *Output {
 		Schema: &model.Schema{
 			HasAdditionalProperties: false,
 			Type:                    []string{"object"},
-			Properties:              model.IncidentTypeV2.Schema().Properties,
+			Properties:              model.IncidentTypeV1.Schema().Properties,
 		},
 		KeyProperties:      []string{"id"},
 		BookmarkProperties: []string{},
 	}
 }
 
-func (s *StreamIncidentTypes) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.IncidentTypesV1ListWithResponse(ctx)
+func (s *StreamIncidentTypes) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.IncidentTypeV1]{
+		Endpoint: Endpoint{Name: "incident_types"},
+		Options:  cfg.Stream("incident_types"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.IncidentTypeV1], error) {
+			response, err := cl.IncidentTypesV1ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing incident types")
+			}
+
+			return &Page[client.IncidentTypeV1]{Results: response.JSON200.IncidentTypes}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing incident types")
-	}
-
-	for _, element := range response.JSON200.IncidentTypes {
-		results = append(results, model.IncidentTypeV1.Serialize(&element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.IncidentTypeV1, _ int) map[string]any {
+		return model.IncidentTypeV1.Serialize(element)
+	}), nil
 }
@@ -5,6 +5,7 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
@@ -24,37 +25,87 @@ func (s *StreamIncidentUpdates) Output() *Output {
 		Schema: &model.Schema{
 			HasAdditionalProperties: false,
 			Type:                    []string{"object"},
-			Properties:              model.IncidentUpdateV2.Schema().Properties,
+			Properties: lo.Assign(model.IncidentUpdateV2.Schema().Properties, map[string]model.Property{
+				"incident_mode": {
+					Types: []string{"string"},
+				},
+			}),
 		},
 		KeyProperties:      []string{"id"},
 		BookmarkProperties: []string{},
 	}
 }
 
-func (s *StreamIncidentUpdates) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		after    *string
-		pageSize int64 = 250
-		results        = []map[string]any{}
-	)
-
-	for {
-		logger.Log("msg", "loading page", "page_size", pageSize, "after", after)
-		page, err := cl.IncidentUpdatesV2ListWithResponse(ctx, &client.IncidentUpdatesV2ListParams{
-			PageSize: &pageSize,
-			After:    after,
-		})
-		if err != nil {
-			return nil, errors.Wrap(err, "listing incident updates")
-		}
+func (s *StreamIncidentUpdates) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	opts := cfg.Stream("incident_updates")
 
-		for _, element := range page.JSON200.IncidentUpdates {
-			results = append(results, model.IncidentUpdateV2.Serialize(element))
-		}
-		if count := len(page.JSON200.IncidentUpdates); count == 0 {
-			return results, nil // end pagination
-		} else {
-			after = lo.ToPtr(page.JSON200.IncidentUpdates[count-1].Id)
+	// Updates can't be filtered by incident mode, so we find the mode of each incident
+	// we're extracting and skip updates for any others.
+	incidents, err := cachedIncidentsMatching(ctx, logger, cl, childOptions(opts), config.IncidentFilters{
+		Mode: config.Filter{"one_of": cfg.Modes()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	modes := lo.SliceToMap(incidents, func(incident client.IncidentV2) (string, client.IncidentV2Mode) {
+		return incident.Id, incident.Mode
+	})
+
+	updates, err := cachedIncidentUpdates(ctx, logger, cl, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]any{}
+	for _, update := range updates {
+		mode, ok := modes[update.IncidentId]
+		if !ok {
+			continue
 		}
+
+		record := model.IncidentUpdateV2.Serialize(update)
+		record["incident_mode"] = string(mode)
+
+		results = append(results, record)
 	}
+
+	return results, nil
+}
+
+// cachedIncidentUpdates loads every incident update, which the incident_metrics stream
+// shares with this one. Only a complete history can be shared, so updates loaded with
+// max_pages are cached apart from it.
+func cachedIncidentUpdates(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig) ([]client.IncidentUpdateV2, error) {
+	return cached(ctx, cacheKey("incident_updates", opts, nil), func() ([]client.IncidentUpdateV2, error) {
+		return listIncidentUpdates(ctx, logger, cl, opts, nil)
+	})
+}
+
+// listIncidentUpdates loads every incident update, optionally only those for a single
+// incident.
+func listIncidentUpdates(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig, incidentID *string) ([]client.IncidentUpdateV2, error) {
+	return Paginator[client.IncidentUpdateV2]{
+		Endpoint: Endpoint{Name: "incident_updates", MaxPageSize: config.MaxPageSizes["incident_updates"]},
+		Options:  opts,
+		Fetch: func(ctx context.Context, req PageRequest) (*Page[client.IncidentUpdateV2], error) {
+			page, err := cl.IncidentUpdatesV2ListWithResponse(ctx, &client.IncidentUpdatesV2ListParams{
+				IncidentId: incidentID,
+				PageSize:   &req.PageSize,
+				After:      req.After,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing incident updates")
+			}
+
+			result := &Page[client.IncidentUpdateV2]{Results: page.JSON200.IncidentUpdates}
+			if meta := page.JSON200.PaginationMeta; meta != nil {
+				result.PaginationMeta = &PaginationMeta{After: meta.After}
+			}
+
+			return result, nil
+		},
+		ID: func(update client.IncidentUpdateV2) string {
+			return update.Id
+		},
+	}.All(ctx, logger)
 }
@@ -2,12 +2,18 @@ package tap
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sort"
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func init() {
@@ -24,91 +30,171 @@ func (s *StreamIncidents) Output() *Output {
 		Schema: &model.Schema{
 			HasAdditionalProperties: false,
 			Type:                    []string{"object"},
-			Properties:              model.IncidentV2.Schema().Properties,
+			// We embed the attachments and updates of each incident, which the API
+			// loads separately.
+			Properties: lo.Assign(model.IncidentV2.Schema().Properties, map[string]model.Property{
+				"attachments": model.Optional(model.ArrayOf(model.IncidentAttachmentV1.Schema())),
+				"updates":     model.Optional(model.ArrayOf(model.IncidentUpdateV2.Schema())),
+			}),
 		},
 		KeyProperties:      []string{"id"},
 		BookmarkProperties: []string{},
 	}
 }
 
-func (s *StreamIncidents) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		after    *string
-		pageSize = int64(250)
-		results  = []map[string]any{}
-	)
-
-	for {
-		logger.Log("msg", "loading page", "page_size", pageSize, "after", after)
-		page, err := cl.IncidentsV2ListWithResponse(ctx, &client.IncidentsV2ListParams{
-			PageSize: &pageSize,
-			After:    after,
-		})
+func (s *StreamIncidents) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	opts := cfg.Stream("incidents")
+
+	incidents, err := cachedIncidents(ctx, logger, cl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]any{}
+	for _, element := range incidents {
+		record, err := s.getRecord(ctx, logger, cl, opts, element)
 		if err != nil {
-			return nil, errors.Wrap(err, "listing incidents")
+			return nil, err
 		}
 
-		for _, element := range page.JSON200.Incidents {
-			attachments, err := s.GetAttachments(ctx, logger, cl, element.Id)
+		results = append(results, record)
+	}
+
+	return results, nil
+}
+
+// getRecord loads the attachments and updates of the incident, to build its record.
+func (s *StreamIncidents) getRecord(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig, element client.IncidentV2) (record map[string]any, err error) {
+	ctx, span := tracer.Start(ctx, "tap.StreamIncidents.getRecord", trace.WithAttributes(
+		attribute.String("incident_id", element.Id)))
+	defer func() { endSpan(span, err) }()
+
+	attachments, err := s.GetAttachments(ctx, logger, cl, childOptions(opts), element.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing incident attachments")
+	}
+
+	updates, err := s.GetIncidentUpdates(ctx, logger, cl, childOptions(opts), element.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing incident updates")
+	}
+
+	record = model.IncidentV2.Serialize(element)
+	record["attachments"] = serializeAll(attachments, model.IncidentAttachmentV1.Serialize)
+	record["updates"] = serializeAll(updates, model.IncidentUpdateV2.Serialize)
+
+	return record, nil
+}
+
+// serializeAll serializes each element, returning nil rather than an empty list when
+// there are none.
+func serializeAll[T any](elements []T, serialize func(T) map[string]any) []map[string]any {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	return lo.Map(elements, func(element T, _ int) map[string]any {
+		return serialize(element)
+	})
+}
+
+// cachedIncidents loads the incidents for the incidents stream, which the streams
+// derived from them share so we only page through them once per sync.
+func cachedIncidents(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]client.IncidentV2, error) {
+	return cachedIncidentsMatching(ctx, logger, cl, cfg.Stream("incidents"), cfg.IncidentFilters())
+}
+
+// cachedIncidentsMatching loads every incident matching the filters, sharing them with
+// any other stream that lists incidents the same way during the sync.
+func cachedIncidentsMatching(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig, filters config.IncidentFilters) ([]client.IncidentV2, error) {
+	return cached(ctx, cacheKey("incidents", opts, filters), func() ([]client.IncidentV2, error) {
+		return listIncidents(ctx, logger, cl, opts, filters)
+	})
+}
+
+// listIncidents loads every incident matching the filters.
+func listIncidents(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig, filters config.IncidentFilters) ([]client.IncidentV2, error) {
+	return Paginator[client.IncidentV2]{
+		Endpoint: Endpoint{Name: "incidents", MaxPageSize: config.MaxPageSizes["incidents"]},
+		Options:  opts,
+		Fetch: func(ctx context.Context, req PageRequest) (*Page[client.IncidentV2], error) {
+			page, err := cl.IncidentsV2ListWithResponse(ctx, &client.IncidentsV2ListParams{
+				PageSize: &req.PageSize,
+				After:    req.After,
+			}, withIncidentFilters(filters))
 			if err != nil {
-				return nil, errors.Wrap(err, "listing incident attachments")
+				return nil, errors.Wrap(err, "listing incidents")
 			}
 
-			updates, err := s.GetIncidentUpdates(ctx, logger, cl, element.Id)
-			if err != nil {
-				return nil, errors.Wrap(err, "listing incident attachments")
+			result := &Page[client.IncidentV2]{Results: page.JSON200.Incidents}
+			if meta := page.JSON200.PaginationMeta; meta != nil {
+				result.PaginationMeta = &PaginationMeta{After: meta.After}
 			}
 
-			results = append(results, model.IncidentV2.Serialize(element, attachments, updates))
-		}
-		if count := len(page.JSON200.Incidents); count == 0 {
-			return results, nil // end pagination
-		} else {
-			after = lo.ToPtr(page.JSON200.Incidents[count-1].Id)
-		}
-	}
+			return result, nil
+		},
+		ID: func(incident client.IncidentV2) string {
+			return incident.Id
+		},
+	}.All(ctx, logger)
 }
 
-func (s *StreamIncidents) GetAttachments(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, incidentId string) ([]client.IncidentAttachmentV1, error) {
-	var (
-		results = []client.IncidentAttachmentV1{}
-	)
+func (s *StreamIncidents) GetAttachments(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig, incidentId string) ([]client.IncidentAttachmentV1, error) {
+	return Paginator[client.IncidentAttachmentV1]{
+		Endpoint: Endpoint{Name: "incident_attachments"},
+		Options:  opts,
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.IncidentAttachmentV1], error) {
+			response, err := cl.IncidentAttachmentsV1ListWithResponse(ctx, &client.IncidentAttachmentsV1ListParams{
+				IncidentId: &incidentId,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing attachments for incidents stream")
+			}
 
-	response, err := cl.IncidentAttachmentsV1ListWithResponse(ctx, &client.IncidentAttachmentsV1ListParams{
-		IncidentId: &incidentId,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "listing attachments for incidents stream")
-	}
+			return &Page[client.IncidentAttachmentV1]{Results: response.JSON200.IncidentAttachments}, nil
+		},
+	}.All(ctx, logger)
+}
 
-	results = append(results, response.JSON200.IncidentAttachments...)
-	return results, nil
+func (s *StreamIncidents) GetIncidentUpdates(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, opts config.StreamConfig, incidentId string) ([]client.IncidentUpdateV2, error) {
+	return listIncidentUpdates(ctx, logger, cl, opts, &incidentId)
 }
 
-func (s *StreamIncidents) GetIncidentUpdates(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, incidentId string) ([]client.IncidentUpdateV2, error) {
-	var (
-		after    *string
-		pageSize int64 = 250
-		results        = []client.IncidentUpdateV2{}
-	)
-
-	for {
-		logger.Log("msg", "loading incident updates page", "page_size", pageSize, "after", after)
-		page, err := cl.IncidentUpdatesV2ListWithResponse(ctx, &client.IncidentUpdatesV2ListParams{
-			IncidentId: &incidentId,
-			PageSize:   &pageSize,
-			After:      after,
-		})
-		if err != nil {
-			return nil, errors.Wrap(err, "listing incident updates")
+// withIncidentFilters adds the configured filters to the request query. The generated
+// client can't serialise the nested filter params, so we encode them ourselves, e.g.
+// status_category[one_of]=live or custom_field[<id>][one_of]=<option-id>.
+func withIncidentFilters(filters config.IncidentFilters) client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		add := func(param string, filter config.Filter) {
+			for operator, values := range filter {
+				for _, value := range values {
+					query.Add(fmt.Sprintf("%s[%s]", param, operator), value)
+				}
+			}
 		}
+		addEach := func(param string, filters map[string]config.Filter) {
+			ids := make([]string, 0, len(filters))
+			for id := range filters {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
 
-		results = append(results, page.JSON200.IncidentUpdates...)
-
-		if count := len(page.JSON200.IncidentUpdates); count == 0 {
-			return results, nil // end pagination
-		} else {
-			after = lo.ToPtr(page.JSON200.IncidentUpdates[count-1].Id)
+			for _, id := range ids {
+				add(fmt.Sprintf("%s[%s]", param, id), filters[id])
+			}
 		}
+
+		add("status", filters.Status)
+		add("status_category", filters.StatusCategory)
+		add("severity", filters.Severity)
+		add("incident_type", filters.IncidentType)
+		addEach("incident_role", filters.IncidentRole)
+		addEach("custom_field", filters.CustomField)
+		add("mode", filters.Mode)
+
+		req.URL.RawQuery = query.Encode()
+
+		return nil
 	}
 }
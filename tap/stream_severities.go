@@ -5,8 +5,10 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
 func init() {
@@ -23,26 +25,31 @@ func (s *StreamSeverities) Output() *Output {
 		Schema: &model.Schema{
 			HasAdditionalProperties: false,
 			Type:                    []string{"object"},
-			Properties:              model.SeverityV2.Schema().Properties,
+			Properties:              model.SeverityV1.Schema().Properties,
 		},
 		KeyProperties:      []string{"id"},
 		BookmarkProperties: []string{},
 	}
 }
 
-func (s *StreamSeverities) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		results = []map[string]any{}
-	)
-
-	response, err := cl.SeveritiesV1ListWithResponse(ctx)
+func (s *StreamSeverities) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	elements, err := Paginator[client.SeverityV1]{
+		Endpoint: Endpoint{Name: "severities"},
+		Options:  cfg.Stream("severities"),
+		Fetch: func(ctx context.Context, _ PageRequest) (*Page[client.SeverityV1], error) {
+			response, err := cl.SeveritiesV1ListWithResponse(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing severities")
+			}
+
+			return &Page[client.SeverityV1]{Results: response.JSON200.Severities}, nil
+		},
+	}.All(ctx, logger)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing severities")
-	}
-
-	for _, element := range response.JSON200.Severities {
-		results = append(results, model.SeverityV1.Serialize(&element))
+		return nil, err
 	}
 
-	return results, nil
+	return lo.Map(elements, func(element client.SeverityV1, _ int) map[string]any {
+		return model.SeverityV1.Serialize(element)
+	}), nil
 }
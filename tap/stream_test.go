@@ -0,0 +1,495 @@
+package tap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Streams", func() {
+	var (
+		ctx    context.Context
+		logger kitlog.Logger
+		data   *fakeapi.Data
+		server *fakeapi.Server
+		cl     *client.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		logger = kitlog.NewNopLogger()
+		data = &fakeapi.Data{}
+	})
+
+	JustBeforeEach(func() {
+		fake := newFakeTap(data, nil)
+		server, cl = fake.Server, fake.Client
+	})
+
+	Describe("StreamAlerts", func() {
+		When("there are no alerts", func() {
+			It("makes a single request", func() {
+				records, err := (&tap.StreamAlerts{}).GetRecords(ctx, logger, cl, config.Config{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+				Expect(server.Requests("/v2/alerts")).To(HaveLen(1))
+			})
+		})
+
+		When("the alerts exactly fill the last page", func() {
+			BeforeEach(func() {
+				data = fakeapi.Generate(1, fakeapi.Size{Alerts: 100})
+			})
+
+			It("does not request an empty page", func() {
+				records, err := (&tap.StreamAlerts{}).GetRecords(ctx, logger, cl, config.Config{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(100))
+				Expect(server.Requests("/v2/alerts")).To(HaveLen(2))
+			})
+		})
+
+		When("the alerts span a partial page", func() {
+			BeforeEach(func() {
+				data = fakeapi.Generate(1, fakeapi.Size{Alerts: 75})
+			})
+
+			It("returns every alert once", func() {
+				records, err := (&tap.StreamAlerts{}).GetRecords(ctx, logger, cl, config.Config{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(75))
+				Expect(records[0]["id"]).To(Equal(data.Alerts[0].Id))
+				Expect(records[74]["id"]).To(Equal(data.Alerts[74].Id))
+			})
+		})
+
+		When("a page fails to load", func() {
+			BeforeEach(func() {
+				data = fakeapi.Generate(1, fakeapi.Size{Alerts: 75})
+			})
+
+			JustBeforeEach(func() {
+				server.Inject(fakeapi.Fault{Path: "/v2/alerts", Status: http.StatusInternalServerError, Skip: 1})
+			})
+
+			It("returns an error", func() {
+				_, err := (&tap.StreamAlerts{}).GetRecords(ctx, logger, cl, config.Config{})
+				Expect(err).To(MatchError(ContainSubstring("listing alerts")))
+			})
+		})
+	})
+
+	Describe("StreamEscalations", func() {
+		BeforeEach(func() {
+			data = fakeapi.Generate(1, fakeapi.Size{Escalations: 120})
+		})
+
+		It("loads every page", func() {
+			records, err := (&tap.StreamEscalations{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(120))
+			Expect(server.Requests("/v2/escalations")).To(HaveLen(3))
+		})
+	})
+
+	Describe("StreamIncidents", func() {
+		BeforeEach(func() {
+			data = fakeapi.Generate(1, fakeapi.Size{Incidents: 20, UpdatesPerIncident: 2, AttachmentsPerIncident: 1})
+		})
+
+		It("stops on the last page of incidents", func() {
+			_, err := (&tap.StreamIncidents{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Requests("/v2/incidents")).To(HaveLen(1))
+		})
+
+		It("includes the updates and attachments of each incident", func() {
+			records, err := (&tap.StreamIncidents{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).NotTo(BeEmpty())
+
+			for _, record := range records {
+				Expect(record["updates"]).To(HaveLen(2))
+				Expect(record["attachments"]).To(HaveLen(1))
+			}
+		})
+
+		It("excludes test and tutorial incidents by default", func() {
+			records, err := (&tap.StreamIncidents{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, record := range records {
+				Expect(record["mode"]).To(BeElementOf(client.IncidentV2ModeStandard, client.IncidentV2ModeRetrospective))
+			}
+		})
+
+		When("filters are configured", func() {
+			var cfg config.Config
+
+			BeforeEach(func() {
+				cfg = config.Config{Streams: map[string]config.StreamConfig{
+					"incidents": {Filters: &config.IncidentFilters{
+						StatusCategory: config.Filter{"one_of": {"live", "closed"}},
+						Mode:           config.Filter{"one_of": {"standard", "retrospective", "test", "tutorial"}},
+						CustomField:    map[string]config.Filter{"01CF": {"one_of": {"01OPT"}}},
+					}},
+				}}
+			})
+
+			It("only loads matching incidents", func() {
+				records, err := (&tap.StreamIncidents{}).GetRecords(ctx, logger, cl, cfg)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := lo.Filter(data.Incidents, func(incident client.IncidentV2, _ int) bool {
+					return lo.Contains([]client.IncidentStatusV2Category{"live", "closed"}, incident.IncidentStatus.Category)
+				})
+				Expect(records).To(HaveLen(len(expected)))
+			})
+
+			It("sends each filter as a query param", func() {
+				_, err := (&tap.StreamIncidents{}).GetRecords(ctx, logger, cl, cfg)
+				Expect(err).NotTo(HaveOccurred())
+
+				query := server.Requests("/v2/incidents")[0].Query
+				Expect(query["status_category[one_of]"]).To(ConsistOf("live", "closed"))
+				Expect(query["mode[one_of]"]).To(ConsistOf("standard", "retrospective", "test", "tutorial"))
+				Expect(query["custom_field[01CF][one_of]"]).To(ConsistOf("01OPT"))
+			})
+		})
+	})
+
+	Describe("streams derived from incidents", func() {
+		BeforeEach(func() {
+			data = fakeapi.Generate(1, fakeapi.Size{Incidents: 20})
+			for idx := range data.Incidents {
+				data.Incidents[idx].Mode = client.IncidentV2ModeStandard
+			}
+
+			data.Incidents[0].IncidentRoleAssignments = []client.IncidentRoleAssignmentV2{
+				{Role: client.EmbeddedIncidentRoleV2{Id: "01ROLELEAD"}, Assignee: &client.UserV2{Id: "01USERLISA"}},
+				{Role: client.EmbeddedIncidentRoleV2{Id: "01ROLECOMMS"}},
+			}
+			data.Incidents[0].CustomFieldEntries = []client.CustomFieldEntryV2{
+				{
+					CustomField: client.CustomFieldTypeInfoV2{Id: "01CFTEAMS", FieldType: "multi_select"},
+					Values: []client.CustomFieldValueV2{
+						{ValueOption: &client.CustomFieldOptionV2{Id: "01OPTPAYMENTS"}},
+						{ValueOption: &client.CustomFieldOptionV2{Id: "01OPTPLATFORM"}},
+					},
+				},
+				{CustomField: client.CustomFieldTypeInfoV2{Id: "01CFEMPTY", FieldType: "text"}},
+			}
+		})
+
+		It("has a record per role assignment", func() {
+			records, err := (&tap.StreamIncidentRoleAssignments{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				SatisfyAll(HaveKeyWithValue("incident_id", data.Incidents[0].Id), HaveKeyWithValue("role_id", "01ROLELEAD")),
+				SatisfyAll(HaveKeyWithValue("incident_id", data.Incidents[0].Id), HaveKeyWithValue("role_id", "01ROLECOMMS")),
+			))
+		})
+
+		It("has a record per custom field value", func() {
+			records, err := (&tap.StreamIncidentCustomFieldValues{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				SatisfyAll(HaveKeyWithValue("custom_field_id", "01CFTEAMS"), HaveKeyWithValue("value_index", 0)),
+				SatisfyAll(HaveKeyWithValue("custom_field_id", "01CFTEAMS"), HaveKeyWithValue("value_index", 1)),
+			))
+		})
+
+		It("has a record per timestamp", func() {
+			records, err := (&tap.StreamIncidentTimestampValues{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(20))
+			Expect(records).To(HaveEach(HaveKeyWithValue("incident_timestamp_id", "01TIMESTAMPREPORTED")))
+		})
+
+		It("are built from the same pages as incidents during a sync", func() {
+			catalog := tap.NewDefaultCatalog(map[string]tap.Stream{
+				"incidents":                    &tap.StreamIncidents{},
+				"incident_role_assignments":    &tap.StreamIncidentRoleAssignments{},
+				"incident_custom_field_values": &tap.StreamIncidentCustomFieldValues{},
+				"incident_timestamp_values":    &tap.StreamIncidentTimestampValues{},
+			})
+			for _, entry := range catalog.Streams {
+				for idx := range *entry.Metadata {
+					(*entry.Metadata)[idx].Metadata.Selected = lo.ToPtr(true)
+				}
+			}
+
+			ol := tap.NewOutputLogger(io.Discard)
+			Expect(tap.Sync(ctx, logger, ol, cl, catalog, nil, config.Config{})).To(Succeed())
+			Expect(server.Requests("/v2/incidents")).To(HaveLen(1))
+		})
+
+		It("share incidents with incident_updates during a sync", func() {
+			catalog := tap.NewDefaultCatalog(map[string]tap.Stream{
+				"incidents":        &tap.StreamIncidents{},
+				"incident_updates": &tap.StreamIncidentUpdates{},
+			})
+
+			ol := tap.NewOutputLogger(io.Discard)
+			Expect(tap.Sync(ctx, logger, ol, cl, catalog, nil, config.Config{})).To(Succeed())
+			Expect(server.Requests("/v2/incidents")).To(HaveLen(1))
+		})
+
+		It("aren't selected by default", func() {
+			catalog := tap.NewDefaultCatalog(map[string]tap.Stream{
+				"incidents":                 &tap.StreamIncidents{},
+				"incident_role_assignments": &tap.StreamIncidentRoleAssignments{},
+			})
+
+			Expect(lo.Map(catalog.GetEnabledStreams(), func(entry tap.CatalogEntry, _ int) string {
+				return entry.Stream
+			})).To(Equal([]string{"incidents"}))
+		})
+	})
+
+	Describe("StreamIncidentMetrics", func() {
+		var (
+			reportedAt time.Time
+			sev1, sev2 *client.SeverityV2
+		)
+
+		BeforeEach(func() {
+			reportedAt = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+			sev1 = &client.SeverityV2{Id: "01SEV1", Name: "Minor", Rank: 1}
+			sev2 = &client.SeverityV2{Id: "01SEV2", Name: "Major", Rank: 2}
+
+			status := func(category client.IncidentStatusV2Category) client.IncidentStatusV2 {
+				return client.IncidentStatusV2{Id: "01STATUS" + string(category), Category: category}
+			}
+			update := func(id string, after time.Duration, category client.IncidentStatusV2Category, severity *client.SeverityV2) client.IncidentUpdateV2 {
+				return client.IncidentUpdateV2{
+					Id: id, IncidentId: "01INC", CreatedAt: reportedAt.Add(after),
+					NewIncidentStatus: status(category), NewSeverity: severity,
+				}
+			}
+
+			data = &fakeapi.Data{
+				Incidents: []client.IncidentV2{
+					{
+						Id: "01INC", Mode: client.IncidentV2ModeStandard, CreatedAt: reportedAt,
+						IncidentStatus: status(client.IncidentStatusV2CategoryClosed), Severity: sev1,
+						IncidentTimestampValues: &[]client.IncidentTimestampWithValueV2{
+							{
+								IncidentTimestamp: client.IncidentTimestampV2{Id: "01REPORTED", Name: "Reported at"},
+								Value:             &client.IncidentTimestampValueV2{Value: lo.ToPtr(reportedAt)},
+							},
+							{
+								IncidentTimestamp: client.IncidentTimestampV2{Id: "01RESOLVED", Name: "Resolved at"},
+								Value:             &client.IncidentTimestampValueV2{Value: lo.ToPtr(reportedAt.Add(70 * time.Minute))},
+							},
+							{
+								IncidentTimestamp: client.IncidentTimestampV2{Id: "01ACCEPTED", Name: "Accepted at"},
+							},
+						},
+					},
+				},
+				// Updates are listed newest first, as the API does.
+				IncidentUpdates: []client.IncidentUpdateV2{
+					update("01UPD4", 70*time.Minute, client.IncidentStatusV2CategoryClosed, sev1),
+					update("01UPD3", 40*time.Minute, client.IncidentStatusV2CategoryLive, sev2),
+					update("01UPD2", 10*time.Minute, client.IncidentStatusV2CategoryLive, sev1),
+					update("01UPD1", 0, client.IncidentStatusV2CategoryTriage, sev1),
+				},
+			}
+		})
+
+		getRecord := func(cfg config.Config) map[string]any {
+			records, err := (&tap.StreamIncidentMetrics{}).GetRecords(ctx, logger, cl, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+
+			return records[0]
+		}
+
+		It("measures the default durations between timestamps", func() {
+			Expect(getRecord(config.Config{})["durations"]).To(ConsistOf(
+				SatisfyAll(HaveKeyWithValue("name", "time_to_acknowledge"), HaveKeyWithValue("seconds", BeNil())),
+				SatisfyAll(HaveKeyWithValue("name", "time_to_resolve"), HaveKeyWithValue("seconds", Equal(lo.ToPtr(int64(4200))))),
+				SatisfyAll(HaveKeyWithValue("name", "time_to_close"), HaveKeyWithValue("seconds", BeNil())),
+			))
+		})
+
+		It("measures configured durations, by timestamp name or ID", func() {
+			record := getRecord(config.Config{Streams: map[string]config.StreamConfig{
+				"incident_metrics": {Durations: []config.TimestampDuration{
+					{Name: "time_to_fix", From: "01REPORTED", To: "resolved at"},
+				}},
+			}})
+
+			Expect(record["durations"]).To(ConsistOf(
+				SatisfyAll(HaveKeyWithValue("name", "time_to_fix"), HaveKeyWithValue("seconds", Equal(lo.ToPtr(int64(4200))))),
+			))
+		})
+
+		It("adds up the time spent in each status category", func() {
+			Expect(getRecord(config.Config{})["status_category_seconds"]).To(SatisfyAll(
+				HaveKeyWithValue("triage", int64(600)),
+				HaveKeyWithValue("live", int64(3600)),
+				HaveKeyWithValue("closed", int64(0)),
+			))
+		})
+
+		It("finds every change of severity", func() {
+			record := getRecord(config.Config{})
+
+			Expect(record["initial_severity"]).To(HaveKeyWithValue("id", "01SEV1"))
+			Expect(record["max_severity"]).To(HaveKeyWithValue("id", "01SEV2"))
+			Expect(record["severity_change_count"]).To(Equal(2))
+			Expect(record["severity_changes"]).To(HaveExactElements(
+				SatisfyAll(HaveKeyWithValue("changed_at", reportedAt.Add(40*time.Minute)), HaveKeyWithValue("to", HaveKeyWithValue("id", "01SEV2"))),
+				SatisfyAll(HaveKeyWithValue("changed_at", reportedAt.Add(70*time.Minute)), HaveKeyWithValue("to", HaveKeyWithValue("id", "01SEV1"))),
+			))
+		})
+
+		When("the first update comes after the incident was reported", func() {
+			BeforeEach(func() {
+				data.IncidentUpdates = data.IncidentUpdates[:3]
+			})
+
+			It("counts the time before it as unknown", func() {
+				Expect(getRecord(config.Config{})["status_category_seconds"]).To(SatisfyAll(
+					HaveKeyWithValue("unknown", int64(600)),
+					HaveKeyWithValue("triage", int64(0)),
+					HaveKeyWithValue("live", int64(3600)),
+				))
+			})
+
+			It("doesn't know the initial severity", func() {
+				record := getRecord(config.Config{})
+
+				Expect(record["initial_severity"]).To(BeNil())
+				Expect(record["max_severity"]).To(HaveKeyWithValue("id", "01SEV2"))
+				Expect(record["severity_change_count"]).To(Equal(2))
+			})
+		})
+
+		It("uses every update during a sync, even if incident_updates has max_pages", func() {
+			catalog := tap.NewDefaultCatalog(map[string]tap.Stream{
+				"incident_updates": &tap.StreamIncidentUpdates{},
+				"incident_metrics": &tap.StreamIncidentMetrics{},
+			})
+			for _, entry := range catalog.Streams {
+				for idx := range *entry.Metadata {
+					(*entry.Metadata)[idx].Metadata.Selected = lo.ToPtr(true)
+				}
+			}
+
+			var buf bytes.Buffer
+			Expect(tap.Sync(ctx, logger, tap.NewOutputLogger(&buf), cl, catalog, nil, config.Config{
+				Streams: map[string]config.StreamConfig{"incident_updates": {PageSize: 2, MaxPages: 1}},
+			})).To(Succeed())
+
+			records := map[string][]map[string]any{}
+			decoder := json.NewDecoder(&buf)
+			for decoder.More() {
+				var op tap.Output
+				Expect(decoder.Decode(&op)).To(Succeed())
+				if op.Type == tap.OutputTypeRecord {
+					records[op.Stream] = append(records[op.Stream], op.Record)
+				}
+			}
+
+			Expect(records["incident_updates"]).To(HaveLen(2))
+			Expect(records["incident_metrics"]).To(ConsistOf(
+				HaveKeyWithValue("status_category_seconds", SatisfyAll(
+					HaveKeyWithValue("triage", BeNumerically("==", 600)),
+					HaveKeyWithValue("live", BeNumerically("==", 3600)),
+				)),
+			))
+		})
+	})
+
+	Describe("incident modes", func() {
+		var cfg config.Config
+
+		BeforeEach(func() {
+			data = fakeapi.Generate(1, fakeapi.Size{Incidents: 40, UpdatesPerIncident: 1, FollowUpsPerIncident: 1})
+			cfg = config.Config{IncidentModes: []string{"standard", "test"}}
+		})
+
+		modeOf := func(incidentID string) client.IncidentV2Mode {
+			incident, _ := lo.Find(data.Incidents, func(incident client.IncidentV2) bool {
+				return incident.Id == incidentID
+			})
+			return incident.Mode
+		}
+		It("loads incidents in the configured modes", func() {
+			records, err := (&tap.StreamIncidents{}).GetRecords(ctx, logger, cl, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(lo.CountBy(data.Incidents, func(incident client.IncidentV2) bool {
+				return lo.Contains([]client.IncidentV2Mode{"standard", "test"}, incident.Mode)
+			})))
+
+			for _, record := range records {
+				Expect(record["mode"]).To(BeElementOf(client.IncidentV2ModeStandard, client.IncidentV2ModeTest))
+			}
+		})
+
+		It("loads follow-ups for each mode, including the mode in each record", func() {
+			records, err := (&tap.StreamFollowUps{}).GetRecords(ctx, logger, cl, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Requests("/v2/follow_ups")).To(HaveLen(2))
+			Expect(records).NotTo(BeEmpty())
+
+			for _, record := range records {
+				Expect(record["incident_mode"]).To(BeElementOf("standard", "test"))
+				Expect(string(modeOf(record["incident_id"].(string)))).To(Equal(record["incident_mode"]))
+			}
+		})
+
+		It("only loads updates for incidents in the configured modes", func() {
+			records, err := (&tap.StreamIncidentUpdates{}).GetRecords(ctx, logger, cl, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(lo.CountBy(data.Incidents, func(incident client.IncidentV2) bool {
+				return lo.Contains([]client.IncidentV2Mode{"standard", "test"}, incident.Mode)
+			})))
+
+			for _, record := range records {
+				Expect(string(modeOf(record["incident_id"].(string)))).To(Equal(record["incident_mode"]))
+			}
+		})
+	})
+
+	Describe("StreamUsers", func() {
+		BeforeEach(func() {
+			data = fakeapi.Generate(1, fakeapi.Size{Users: 300})
+		})
+
+		It("loads every page", func() {
+			records, err := (&tap.StreamUsers{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(300))
+			Expect(server.Requests("/v2/users")).To(HaveLen(2))
+		})
+	})
+
+	Describe("StreamCustomFieldOptions", func() {
+		BeforeEach(func() {
+			data = fakeapi.Generate(1, fakeapi.Size{CustomFields: 3, OptionsPerCustomField: 4})
+		})
+
+		It("loads the options for every custom field", func() {
+			records, err := (&tap.StreamCustomFieldOptions{}).GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(12))
+		})
+	})
+})
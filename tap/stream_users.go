@@ -5,6 +5,7 @@ import (
 
 	kitlog "github.com/go-kit/log"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
 	"github.com/incident-io/singer-tap/model"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
@@ -24,37 +25,37 @@ func (s *StreamUsers) Output() *Output {
 		Schema: &model.Schema{
 			HasAdditionalProperties: false,
 			Type:                    []string{"object"},
-			Properties:              model.UserV1.Schema().Properties,
+			Properties:              model.UserWithRolesV2.Schema().Properties,
 		},
 		KeyProperties:      []string{"id"},
 		BookmarkProperties: []string{},
 	}
 }
 
-func (s *StreamUsers) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses) ([]map[string]any, error) {
-	var (
-		after    *string
-		pageSize int64 = 250
-		results        = []map[string]any{}
-	)
-
-	for {
-		logger.Log("msg", "loading page", "page_size", pageSize, "after", after)
-		page, err := cl.UsersV2ListWithResponse(ctx, &client.UsersV2ListParams{
-			PageSize: &pageSize,
-			After:    after,
-		})
-		if err != nil {
-			return nil, errors.Wrap(err, "listing incidents")
-		}
-
-		for _, element := range page.JSON200.Users {
-			results = append(results, model.UserWithRolesV2.Serialize(element))
-		}
-		if count := len(page.JSON200.Users); count == 0 {
-			return results, nil // end pagination
-		} else {
-			after = lo.ToPtr(page.JSON200.Users[count-1].Id)
-		}
+func (s *StreamUsers) GetRecords(ctx context.Context, logger kitlog.Logger, cl *client.ClientWithResponses, cfg config.Config) ([]map[string]any, error) {
+	users, err := Paginator[client.UserWithRolesV2]{
+		Endpoint: Endpoint{Name: "users", MaxPageSize: config.MaxPageSizes["users"]},
+		Options:  cfg.Stream("users"),
+		Fetch: func(ctx context.Context, req PageRequest) (*Page[client.UserWithRolesV2], error) {
+			page, err := cl.UsersV2ListWithResponse(ctx, &client.UsersV2ListParams{
+				PageSize: &req.PageSize,
+				After:    req.After,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "listing users")
+			}
+
+			return &Page[client.UserWithRolesV2]{
+				Results:        page.JSON200.Users,
+				PaginationMeta: &PaginationMeta{After: page.JSON200.PaginationMeta.After},
+			}, nil
+		},
+	}.All(ctx, logger)
+	if err != nil {
+		return nil, err
 	}
+
+	return lo.Map(users, func(user client.UserWithRolesV2, _ int) map[string]any {
+		return model.UserWithRolesV2.Serialize(user)
+	}), nil
 }
@@ -0,0 +1,86 @@
+package tap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "tap")
+}
+
+// fakeTap syncs from the fake API, so tests only need to say what it serves, which
+// streams are in the catalog, and how each sync is configured and output.
+type fakeTap struct {
+	Server  *fakeapi.Server
+	Client  *client.ClientWithResponses
+	Catalog *tap.Catalog
+	// Context and Logger are what each sync is run with.
+	Context context.Context
+	Logger  kitlog.Logger
+
+	url string
+}
+
+// newFakeTap serves the data from the fake API until the end of the test, with a default
+// catalog of the streams. Failed requests are retried without waiting, so faults don't
+// slow the tests down.
+func newFakeTap(data *fakeapi.Data, streams map[string]tap.Stream, opts ...fakeapi.Option) *fakeTap {
+	server := fakeapi.New(data, opts...)
+	httpServer := httptest.NewServer(server)
+	DeferCleanup(httpServer.Close)
+
+	t := &fakeTap{
+		Server:  server,
+		Catalog: tap.NewDefaultCatalog(streams),
+		Context: client.WithRetryPolicy(context.Background(), client.RetryPolicy{Max: 3}),
+		Logger:  kitlog.NewNopLogger(),
+		url:     httpServer.URL,
+	}
+
+	return t.WithClient()
+}
+
+// WithClient replaces the client with one built with the options.
+func (t *fakeTap) WithClient(opts ...client.ClientOption) *fakeTap {
+	var err error
+	t.Client, err = client.New(t.Context, "api-key", t.url, "test", opts...)
+	Expect(err).NotTo(HaveOccurred())
+
+	return t
+}
+
+// Sync syncs the catalog with the config, from the state if given.
+func (t *fakeTap) Sync(ol *tap.OutputLogger, state *tap.State, cfg config.Config) error {
+	return tap.Sync(t.Context, t.Logger, ol, t.Client, t.Catalog, state, cfg)
+}
+
+// SyncOutputs syncs as Sync does, expecting it to succeed, and returns every message
+// that was output.
+func (t *fakeTap) SyncOutputs(state *tap.State, cfg config.Config) []tap.Output {
+	var buf bytes.Buffer
+	Expect(t.Sync(tap.NewOutputLogger(&buf), state, cfg)).To(Succeed())
+
+	outputs := []tap.Output{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var op tap.Output
+		Expect(decoder.Decode(&op)).To(Succeed())
+		outputs = append(outputs, op)
+	}
+
+	return outputs
+}
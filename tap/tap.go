@@ -5,41 +5,121 @@ import (
 	"time"
 
 	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func Sync(ctx context.Context, logger kitlog.Logger, ol *OutputLogger, cl *client.ClientWithResponses, catalog *Catalog) error {
+func Sync(ctx context.Context, logger kitlog.Logger, ol *OutputLogger, cl *client.ClientWithResponses, catalog *Catalog, state *State, cfg config.Config) (err error) {
+	ctx, span := tracer.Start(ctx, "tap.Sync")
+	defer func() { endSpan(span, err) }()
+
 	// If we weren't given a catalog, create a default one and use that
 	if catalog == nil {
 		catalog = NewDefaultCatalog(streams)
 	}
 
+	// Refuse to sync records targets couldn't upsert
+	if err := catalog.ValidateSelection(streams); err != nil {
+		return err
+	}
+
 	// We only want to sync enabled streams
 	enabledStreams := catalog.GetEnabledStreams()
 
+	// Refuse to sync with transforms we can't apply as configured
+	transformers, err := resolveTransformers(streams, enabledStreams, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Config for a stream we don't know about is most likely a typo, which would
+	// otherwise be silently ignored.
+	for name := range cfg.Streams {
+		if _, ok := streams[name]; !ok {
+			level.Warn(logger).Log("msg", "config provided for unknown stream, ignoring", "stream", name)
+		}
+	}
+
+	// Start from the state of the last sync, so bookmarks of streams we don't sync this
+	// time are kept for the next.
+	state = state.Clone()
+
+	// Streams derived from the same responses share them, rather than loading them again.
+	ctx = withSyncCache(ctx)
+
+	summaries := []StreamSummary{}
+
 	for _, catalogEntry := range enabledStreams {
+		if _, ok := streams[catalogEntry.Stream]; !ok {
+			level.Warn(logger).Log("msg", "catalog includes a stream that no longer exists, skipping", "stream", catalogEntry.Stream)
+			continue
+		}
+
 		// Use a filter to ensure we only output the fields we want
 		stream := Filter{
 			Stream:       streams[catalogEntry.Stream],
 			CatalogEntry: catalogEntry,
+			Transformer:  transformers[catalogEntry.Stream],
+		}
+		if cfg.FlatteningEnabled {
+			stream.Flattener = &Flattener{MaxDepth: cfg.FlatteningMaxDepth}
 		}
 
 		logger := kitlog.With(logger, "stream", catalogEntry.Stream)
 
-		logger.Log("msg", "outputting schema")
-		if err := ol.Log(stream.Output()); err != nil {
+		now := time.Now().UTC()
+		table := newFullTable(stream.Output(), cfg, state.Bookmarks[catalogEntry.Stream], now)
+
+		schema := stream.Output()
+		if table != nil {
+			schema = table.Schema(schema)
+		}
+
+		level.Debug(logger).Log("msg", "outputting schema")
+		if err := ol.Log(schema); err != nil {
 			return err
 		}
 
-		timeExtracted := time.Now().UTC().Format(time.RFC3339)
-		logger.Log("msg", "loading records", "time_extracted", timeExtracted)
+		if table != nil {
+			for _, op := range table.Before() {
+				if err := ol.Log(op); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Records are either output directly or, if configured, written to batch files.
+		// Batch files only hold the records themselves, so streams with table versions are
+		// always output directly, keeping the version on each record.
+		var out interface{ Log(*Output) error } = ol
+		var batch *BatchWriter
+		if cfg.BatchConfig != nil && (table == nil || !table.Versioned()) {
+			var err error
+			batch, err = NewBatchWriter(ol, *cfg.BatchConfig, catalogEntry.Stream)
+			if err != nil {
+				return err
+			}
+			out = batch
+		}
 
-		records, err := stream.GetRecords(ctx, logger, cl)
+		timeExtracted := now.Format(time.RFC3339)
+		level.Info(logger).Log("msg", "loading records", "time_extracted", timeExtracted)
+
+		ctx, stats := withStreamStats(ctx, ol, catalogEntry.Stream)
+		ctx, span := tracer.Start(ctx, "tap.GetRecords", trace.WithAttributes(
+			attribute.String("stream", catalogEntry.Stream)))
+		records, err := stream.GetRecords(ctx, logger, cl, cfg)
+		span.SetAttributes(attribute.Int("records", len(records)))
+		endSpan(span, err)
 		if err != nil {
 			return err
 		}
 
-		logger.Log("msg", "outputting records", "count", len(records))
+		level.Info(logger).Log("msg", "outputting records", "records", len(records))
 		for _, record := range records {
 			op := &Output{
 				Type:          OutputTypeRecord,
@@ -47,17 +127,75 @@ func Sync(ctx context.Context, logger kitlog.Logger, ol *OutputLogger, cl *clien
 				Record:        record,
 				TimeExtracted: timeExtracted,
 			}
-			if err := ol.Log(op); err != nil {
+			if table != nil {
+				op = table.Record(op)
+			}
+			if err := out.Log(op); err != nil {
+				return err
+			}
+		}
+
+		if table != nil {
+			after, bookmark := table.After(records, timeExtracted)
+			if deleted := lo.CountBy(after, func(op *Output) bool { return op.Type == OutputTypeRecord }); deleted > 0 {
+				level.Info(logger).Log("msg", "outputting deleted records", "records", deleted)
+			}
+			for _, op := range after {
+				if err := out.Log(op); err != nil {
+					return err
+				}
+			}
+
+			state.Bookmarks[catalogEntry.Stream] = bookmark
+			if err := out.Log(&Output{Type: OutputTypeState, Value: state}); err != nil {
+				return err
+			}
+		}
+
+		if batch != nil {
+			if err := batch.Close(); err != nil {
 				return err
 			}
 		}
+
+		if err := ol.Metric(Metric{
+			Type:   MetricTypeCounter,
+			Metric: "record_count",
+			Value:  len(records),
+			Tags:   map[string]any{"stream": catalogEntry.Stream},
+		}); err != nil {
+			return err
+		}
+
+		summary := stats.finish(len(records))
+		if err := ol.Metric(Metric{
+			Type:   MetricTypeTimer,
+			Metric: "sync_duration",
+			Value:  summary.Elapsed.Seconds(),
+			Tags:   map[string]any{"stream": catalogEntry.Stream},
+		}); err != nil {
+			return err
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	for _, summary := range summaries {
+		level.Info(logger).Log("msg", "synced stream", "stream", summary.Stream, "records", summary.Records,
+			"pages", summary.Pages, "requests", summary.Requests, "retries", summary.Retries,
+			"elapsed", summary.Elapsed.Round(time.Millisecond))
 	}
 
 	return nil
 }
 
+// DefaultCatalog is the catalog we discover, with every stream the tap can sync.
+func DefaultCatalog() *Catalog {
+	return NewDefaultCatalog(streams)
+}
+
 func Discover(ctx context.Context, logger kitlog.Logger, ol *OutputLogger) error {
-	catalog := NewDefaultCatalog(streams)
+	catalog := DefaultCatalog()
 
 	if err := ol.CataLog(catalog); err != nil {
 		return err
@@ -0,0 +1,22 @@
+package tap
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates spans for the sync, each stream and each page we load. It does nothing
+// until a tracer provider is registered with otel.SetTracerProvider, as the app does when
+// the OTEL_ environment variables configure an exporter.
+var tracer = otel.Tracer("github.com/incident-io/singer-tap/tap")
+
+// endSpan records the error on the span, if there was one, before ending it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
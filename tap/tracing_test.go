@@ -0,0 +1,106 @@
+package tap_test
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	// Tracers created before the first tracer provider is registered only ever use that
+	// one, so we share a single provider between tests.
+	spanExporter = tracetest.NewInMemoryExporter()
+	registerOnce sync.Once
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		fake *fakeTap
+	)
+
+	BeforeEach(func() {
+		registerOnce.Do(func() {
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+		})
+		spanExporter.Reset()
+
+		data := fakeapi.Generate(1, fakeapi.Size{
+			Incidents: 2, UpdatesPerIncident: 1, AttachmentsPerIncident: 1,
+		})
+		for idx := range data.Incidents {
+			data.Incidents[idx].Mode = client.IncidentV2ModeStandard
+		}
+
+		fake = newFakeTap(data, map[string]tap.Stream{"incidents": &tap.StreamIncidents{}}).WithClient(client.WithTracing())
+	})
+
+	sync := func() error {
+		return fake.Sync(tap.NewOutputLogger(io.Discard), nil, config.Config{})
+	}
+
+	named := func(name string) []tracetest.SpanStub {
+		return lo.Filter(spanExporter.GetSpans(), func(span tracetest.SpanStub, _ int) bool {
+			return span.Name == name
+		})
+	}
+
+	It("creates spans for the sync, streams, pages and requests", func() {
+		Expect(sync()).To(Succeed())
+
+		syncs := named("tap.Sync")
+		Expect(syncs).To(HaveLen(1))
+
+		streams := named("tap.GetRecords")
+		Expect(streams).To(HaveLen(1))
+		Expect(streams[0].Parent.SpanID()).To(Equal(syncs[0].SpanContext.SpanID()))
+		Expect(streams[0].Attributes).To(ContainElement(attribute.String("stream", "incidents")))
+
+		// Each incident loads its attachments and updates in a span of its own.
+		children := named("tap.StreamIncidents.getRecord")
+		Expect(children).To(HaveLen(2))
+		for _, child := range children {
+			Expect(child.Parent.SpanID()).To(Equal(streams[0].SpanContext.SpanID()))
+		}
+
+		pages := named("tap.Paginator.fetch")
+		Expect(pages).To(HaveLen(5))
+
+		// Every request belongs to the page that made it.
+		requests := lo.Filter(spanExporter.GetSpans(), func(span tracetest.SpanStub, _ int) bool {
+			return span.SpanKind == trace.SpanKindClient
+		})
+		Expect(requests).To(HaveLen(len(pages)))
+		pageIDs := lo.Map(pages, func(span tracetest.SpanStub, _ int) trace.SpanID {
+			return span.SpanContext.SpanID()
+		})
+		for _, request := range requests {
+			Expect(pageIDs).To(ContainElement(request.Parent.SpanID()))
+		}
+	})
+
+	It("records errors on the spans they failed", func() {
+		fake.Server.Inject(fakeapi.Fault{Path: "/v2/incidents", Status: http.StatusInternalServerError})
+		Expect(sync()).NotTo(Succeed())
+
+		for _, name := range []string{"tap.Sync", "tap.GetRecords", "tap.Paginator.fetch"} {
+			spans := named(name)
+			Expect(spans).To(HaveLen(1), name)
+			Expect(spans[0].Status.Code).To(Equal(codes.Error), name)
+		}
+	})
+})
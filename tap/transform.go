@@ -0,0 +1,333 @@
+package tap
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+// Transformer applies the configured transforms to a stream, changing both its schema
+// and records so they continue to match.
+//
+// Transforms are resolved against the stream's schema up front into an action for each
+// property path, such as "creator.user.email" or "custom_roles[].name", where the last
+// transform to match a path wins.
+type Transformer struct {
+	key     []byte
+	actions map[string]config.TransformAction
+	// parents are the paths of objects with actions below them, so we only walk the
+	// parts of each record that need it.
+	parents map[string]bool
+}
+
+// NewTransformer resolves the transforms and presets that apply to the stream against
+// its schema, returning nil if none of them match any of its properties. Key properties
+// can't be dropped or nulled, as records could no longer be told apart.
+func NewTransformer(stream string, properties map[string]model.Property, keyProperties []string, cfg config.Config) (*Transformer, error) {
+	transforms := []config.Transform{}
+	for _, preset := range cfg.TransformPresets {
+		if preset == config.TransformPresetPII {
+			transforms = append(transforms, piiTransforms(properties)...)
+		}
+	}
+	transforms = append(transforms, lo.Filter(cfg.Transforms, func(transform config.Transform, _ int) bool {
+		return transform.AppliesTo(stream)
+	})...)
+
+	t := &Transformer{
+		key:     []byte(cfg.HashKey),
+		actions: map[string]config.TransformAction{},
+		parents: map[string]bool{},
+	}
+	walkProperties("", properties, func(path string, _ model.Property) {
+		for _, transform := range transforms {
+			if matchPath(strings.Split(transform.Path, "."), strings.Split(path, ".")) {
+				t.actions[path] = transform.Action
+			}
+		}
+	})
+
+	for _, key := range keyProperties {
+		if action := t.actions[key]; action == config.TransformDrop || action == config.TransformNull {
+			return nil, fmt.Errorf("transforms can't %s %q, as it's a key property of the %s stream", action, key, stream)
+		}
+	}
+
+	if len(t.actions) == 0 {
+		return nil, nil
+	}
+
+	for path := range t.actions {
+		for idx := strings.LastIndex(path, "."); idx > 0; idx = strings.LastIndex(path, ".") {
+			path = path[:idx]
+			t.parents[strings.TrimSuffix(path, "[]")] = true
+		}
+	}
+
+	return t, nil
+}
+
+// resolveTransformers resolves the transformer for each enabled stream, so a bad
+// transform fails the sync before anything is output. Transforms that match nothing in
+// the streams they apply to are most likely typos, which would otherwise leave the data
+// they were meant to protect untouched.
+func resolveTransformers(streams map[string]Stream, entries []CatalogEntry, cfg config.Config) (map[string]*Transformer, error) {
+	transformers := map[string]*Transformer{}
+	matched := make([]bool, len(cfg.Transforms))
+	applied := make([]bool, len(cfg.Transforms))
+
+	for _, entry := range entries {
+		stream, ok := streams[entry.Stream]
+		if !ok {
+			continue
+		}
+
+		output := stream.Output()
+		transformer, err := NewTransformer(entry.Stream, output.Schema.Properties, output.KeyProperties, cfg)
+		if err != nil {
+			return nil, err
+		}
+		transformers[entry.Stream] = transformer
+
+		for idx, transform := range cfg.Transforms {
+			if !transform.AppliesTo(entry.Stream) {
+				continue
+			}
+
+			applied[idx] = true
+			walkProperties("", output.Schema.Properties, func(path string, _ model.Property) {
+				if matchPath(strings.Split(transform.Path, "."), strings.Split(path, ".")) {
+					matched[idx] = true
+				}
+			})
+		}
+	}
+
+	unmatched := []string{}
+	for idx, transform := range cfg.Transforms {
+		if applied[idx] && !matched[idx] {
+			unmatched = append(unmatched, transform.Path)
+		}
+	}
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("transforms match no properties of the streams they apply to: %s (check their paths against the schema from --discover)",
+			strings.Join(unmatched, ", "))
+	}
+
+	return transformers, nil
+}
+
+// piiTransforms finds every user in the schema, being any object with an email, and
+// hashes their email and Slack user ID while masking their name.
+func piiTransforms(properties map[string]model.Property) []config.Transform {
+	transforms := []config.Transform{}
+	addUser := func(prefix string, properties map[string]model.Property) {
+		if _, ok := properties["email"]; !ok {
+			return
+		}
+
+		for name, action := range map[string]config.TransformAction{
+			"email":         config.TransformHash,
+			"slack_user_id": config.TransformHash,
+			"name":          config.TransformMask,
+		} {
+			if _, ok := properties[name]; ok {
+				transforms = append(transforms, config.Transform{Path: joinPath(prefix, name), Action: action})
+			}
+		}
+	}
+
+	addUser("", properties)
+	walkProperties("", properties, func(path string, property model.Property) {
+		addUser(path, property.Properties)
+		if property.Items != nil {
+			addUser(path+"[]", property.Items.Properties)
+		}
+	})
+
+	return transforms
+}
+
+// Schema returns the properties as they'll be after transforming: dropped properties
+// are removed, nulled properties are nullable, and hashed or masked properties become
+// strings.
+func (t *Transformer) Schema(properties map[string]model.Property) map[string]model.Property {
+	return t.schema("", properties)
+}
+
+func (t *Transformer) schema(prefix string, properties map[string]model.Property) map[string]model.Property {
+	transformed := map[string]model.Property{}
+	for name, property := range properties {
+		path := joinPath(prefix, name)
+
+		switch t.actions[path] {
+		case config.TransformDrop:
+			continue
+		case config.TransformNull:
+			property = model.Optional(property)
+		case config.TransformHash, config.TransformMask:
+			types := []string{"string"}
+			if lo.Contains(property.Types, "null") {
+				types = append(types, "null")
+			}
+			property = model.Property{Types: types}
+		default:
+			if len(property.Properties) > 0 {
+				property.Properties = t.schema(path, property.Properties)
+			}
+			if property.Items != nil && len(property.Items.Properties) > 0 {
+				property.Items = &model.ArrayItem{
+					Type:       property.Items.Type,
+					Properties: t.schema(path+"[]", property.Items.Properties),
+				}
+			}
+		}
+
+		transformed[name] = property
+	}
+
+	return transformed
+}
+
+// Record transforms the record in place, returning it for convenience.
+func (t *Transformer) Record(record map[string]any) map[string]any {
+	t.record("", record)
+
+	return record
+}
+
+func (t *Transformer) record(prefix string, record map[string]any) {
+	for name, value := range record {
+		path := joinPath(prefix, name)
+
+		switch t.actions[path] {
+		case config.TransformDrop:
+			delete(record, name)
+		case config.TransformNull:
+			record[name] = nil
+		case config.TransformHash:
+			record[name] = t.hash(value)
+		case config.TransformMask:
+			record[name] = mask(value)
+		default:
+			if t.parents[path] {
+				t.nested(path, value)
+			}
+		}
+	}
+}
+
+func (t *Transformer) nested(path string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		t.record(path, value)
+	case []map[string]any:
+		for _, item := range value {
+			t.record(path+"[]", item)
+		}
+	case []any:
+		for _, item := range value {
+			if item, ok := item.(map[string]any); ok {
+				t.record(path+"[]", item)
+			}
+		}
+	}
+}
+
+// hash returns the hex encoded HMAC-SHA256 of the value, so the same value always hashes
+// the same way for a given key.
+func (t *Transformer) hash(value any) any {
+	text := stringValue(value)
+	if text == nil {
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(*text))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// mask keeps the first character of the value, replacing the rest with asterisks.
+func mask(value any) any {
+	text := stringValue(value)
+	if text == nil {
+		return nil
+	}
+
+	runes := []rune(*text)
+	if len(runes) <= 1 {
+		return strings.Repeat("*", len(runes))
+	}
+
+	return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+}
+
+// stringValue returns the value as a string, or nil if it is null. Values other than
+// strings are represented by their JSON, as that's how they'd be output otherwise.
+func stringValue(value any) *string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return &text
+	}
+
+	return lo.ToPtr(string(data))
+}
+
+// walkProperties calls visit for every property in the schema, however deeply nested,
+// including those of objects in arrays.
+func walkProperties(prefix string, properties map[string]model.Property, visit func(path string, property model.Property)) {
+	for name, property := range properties {
+		path := joinPath(prefix, name)
+		visit(path, property)
+
+		walkProperties(path, property.Properties, visit)
+		if property.Items != nil {
+			walkProperties(path+"[]", property.Items.Properties, visit)
+		}
+	}
+}
+
+// matchPath returns whether the path matches the pattern, where "*" matches any one
+// property and "**" any number of them.
+func matchPath(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		for idx := 0; idx <= len(path); idx++ {
+			if matchPath(pattern[1:], path[idx:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(path) == 0 || (pattern[0] != "*" && pattern[0] != path[0]) {
+		return false
+	}
+
+	return matchPath(pattern[1:], path[1:])
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
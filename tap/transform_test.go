@@ -0,0 +1,243 @@
+package tap_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transformer", func() {
+	var (
+		cfg        config.Config
+		properties map[string]model.Property
+	)
+
+	hash := func(value string) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	BeforeEach(func() {
+		cfg = config.Config{HashKey: "secret"}
+		properties = map[string]model.Property{
+			"id":      {Types: []string{"string"}},
+			"summary": model.Optional(model.Property{Types: []string{"string"}}),
+			"creator": {
+				Types: []string{"object"},
+				Properties: map[string]model.Property{
+					"user": model.Optional(model.Property{
+						Types: []string{"object"},
+						Properties: map[string]model.Property{
+							"name":  {Types: []string{"string"}},
+							"email": model.Optional(model.Property{Types: []string{"string"}}),
+						},
+					}),
+				},
+			},
+			"custom_roles": model.ArrayOf(model.Property{
+				Types: []string{"object"},
+				Properties: map[string]model.Property{
+					"name": {Types: []string{"string"}},
+				},
+			}),
+		}
+	})
+
+	It("returns nil when no transforms match", func() {
+		cfg.Transforms = []config.Transform{{Path: "missing", Action: config.TransformDrop}}
+		Expect(tap.NewTransformer("incidents", properties, []string{"id"}, cfg)).To(BeNil())
+	})
+
+	It("only applies transforms to their streams", func() {
+		cfg.Transforms = []config.Transform{{Path: "summary", Action: config.TransformDrop, Streams: []string{"incidents"}}}
+		Expect(tap.NewTransformer("users", properties, []string{"id"}, cfg)).To(BeNil())
+		Expect(tap.NewTransformer("incidents", properties, []string{"id"}, cfg)).NotTo(BeNil())
+	})
+
+	It("returns an error for transforms that drop or null a key property", func() {
+		for _, transform := range []config.Transform{
+			{Path: "id", Action: config.TransformDrop},
+			{Path: "*", Action: config.TransformNull},
+		} {
+			cfg.Transforms = []config.Transform{transform}
+			_, err := tap.NewTransformer("incidents", properties, []string{"id"}, cfg)
+			Expect(err).To(MatchError(ContainSubstring(`can't %s "id", as it's a key property of the incidents stream`, transform.Action)))
+		}
+
+		cfg.Transforms = []config.Transform{{Path: "id", Action: config.TransformHash}}
+		Expect(tap.NewTransformer("incidents", properties, []string{"id"}, cfg)).NotTo(BeNil())
+	})
+
+	Context("with each action", func() {
+		var transformer *tap.Transformer
+
+		BeforeEach(func() {
+			cfg.Transforms = []config.Transform{
+				{Path: "summary", Action: config.TransformDrop},
+				{Path: "**.email", Action: config.TransformHash},
+				{Path: "creator.*.name", Action: config.TransformNull},
+				{Path: "custom_roles[].name", Action: config.TransformMask},
+			}
+			var err error
+			transformer, err = tap.NewTransformer("incidents", properties, []string{"id"}, cfg)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adjusts the schema to match", func() {
+			schema := transformer.Schema(properties)
+			Expect(schema).NotTo(HaveKey("summary"))
+
+			user := schema["creator"].Properties["user"].Properties
+			Expect(user["email"]).To(Equal(model.Optional(model.Property{Types: []string{"string"}})))
+			Expect(user["name"]).To(Equal(model.Optional(model.Property{Types: []string{"string"}})))
+			Expect(schema["custom_roles"].Items.Properties["name"]).To(Equal(model.Property{Types: []string{"string"}}))
+		})
+
+		It("transforms records, leaving null values as null", func() {
+			record := transformer.Record(map[string]any{
+				"id":      "01INC",
+				"summary": lo.ToPtr("Lisa broke it"),
+				"creator": map[string]any{
+					"user": map[string]any{"name": "Lisa", "email": lo.ToPtr("lisa@example.com")},
+				},
+				"custom_roles": []map[string]any{{"name": "Admin"}},
+			})
+
+			Expect(record).To(Equal(map[string]any{
+				"id": "01INC",
+				"creator": map[string]any{
+					"user": map[string]any{"name": nil, "email": hash("lisa@example.com")},
+				},
+				"custom_roles": []map[string]any{{"name": "A****"}},
+			}))
+
+			record = transformer.Record(map[string]any{
+				"creator": map[string]any{"user": map[string]any{"email": (*string)(nil)}},
+			})
+			Expect(record).To(Equal(map[string]any{
+				"creator": map[string]any{"user": map[string]any{"email": nil}},
+			}))
+		})
+	})
+
+	Context("with the pii preset", func() {
+		It("hashes emails and masks names of any object with an email", func() {
+			cfg.TransformPresets = []string{config.TransformPresetPII}
+			transformer, err := tap.NewTransformer("incidents", properties, []string{"id"}, cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			record := transformer.Record(map[string]any{
+				"id": "01INC",
+				"creator": map[string]any{
+					"user": map[string]any{"name": "Lisa", "email": "lisa@example.com"},
+				},
+				"custom_roles": []map[string]any{{"name": "Admin"}},
+			})
+
+			Expect(record).To(Equal(map[string]any{
+				"id": "01INC",
+				"creator": map[string]any{
+					"user": map[string]any{"name": "L***", "email": hash("lisa@example.com")},
+				},
+				"custom_roles": []map[string]any{{"name": "Admin"}},
+			}))
+		})
+	})
+})
+
+var _ = Describe("Transformed streams", func() {
+	It("output records matching their transformed schema", func() {
+		fake := newFakeTap(fakeapi.Generate(1, fakeapi.Size{
+			Incidents: 10, UpdatesPerIncident: 1, AttachmentsPerIncident: 1,
+		}), map[string]tap.Stream{"incidents": &tap.StreamIncidents{}})
+
+		cfg := config.Config{
+			TransformPresets: []string{config.TransformPresetPII},
+			Transforms:       []config.Transform{{Path: "summary", Action: config.TransformNull}},
+			HashKey:          "secret",
+		}
+
+		incidents := (&tap.StreamIncidents{}).Output()
+		transformer, err := tap.NewTransformer("incidents", incidents.Schema.Properties, incidents.KeyProperties, cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		stream := tap.Filter{
+			Stream:       &tap.StreamIncidents{},
+			CatalogEntry: fake.Catalog.Streams[0],
+			Transformer:  transformer,
+			Flattener:    &tap.Flattener{},
+		}
+
+		output := stream.Output()
+		validator := tap.NewRecordValidator()
+		Expect(validator.Observe(output)).To(Succeed())
+
+		records, err := stream.GetRecords(fake.Context, fake.Logger, fake.Client, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).NotTo(BeEmpty())
+
+		for _, record := range records {
+			Expect(record["summary"]).To(BeNil())
+			Expect(record["creator__user__email"]).To(MatchRegexp(`^[0-9a-f]{64}$`))
+			Expect(record["creator__user__name"]).To(MatchRegexp(`^A\*+$`))
+			Expect(validator.Validate("incidents", record)).To(Succeed())
+		}
+		Expect(validator.Violations()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Transforms during a sync", func() {
+	var (
+		fake *fakeTap
+		cfg  config.Config
+		buf  bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		buf.Reset()
+		cfg = config.Config{HashKey: "secret"}
+
+		fake = newFakeTap(fakeapi.Generate(1, fakeapi.Size{Users: 5, CustomFields: 2}), map[string]tap.Stream{
+			"users":         &tap.StreamUsers{},
+			"custom_fields": &tap.StreamCustomFields{},
+		})
+	})
+
+	sync := func() error {
+		return fake.Sync(tap.NewOutputLogger(&buf), nil, cfg)
+	}
+
+	It("fails before outputting anything if a transform drops a key property", func() {
+		cfg.Transforms = []config.Transform{{Path: "id", Action: config.TransformDrop, Streams: []string{"users"}}}
+
+		Expect(sync()).To(MatchError(ContainSubstring(`can't drop "id", as it's a key property of the users stream`)))
+		Expect(buf.Len()).To(BeZero())
+	})
+
+	It("fails before outputting anything if a transform matches no properties", func() {
+		cfg.Transforms = []config.Transform{
+			{Path: "email", Action: config.TransformHash},
+			{Path: "emial", Action: config.TransformHash, Streams: []string{"users"}},
+		}
+
+		Expect(sync()).To(MatchError(ContainSubstring("transforms match no properties of the streams they apply to: emial")))
+		Expect(buf.Len()).To(BeZero())
+	})
+
+	It("ignores transforms for streams that aren't selected", func() {
+		cfg.Transforms = []config.Transform{{Path: "emial", Action: config.TransformHash, Streams: []string{"incidents"}}}
+
+		Expect(sync()).To(Succeed())
+	})
+})
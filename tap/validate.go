@@ -0,0 +1,241 @@
+package tap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+// ViolationKind describes how a record disagrees with its schema.
+type ViolationKind string
+
+var (
+	ViolationTypeMismatch  ViolationKind = "type_mismatch"
+	ViolationInvalidFormat ViolationKind = "invalid_format"
+	ViolationUndeclaredKey ViolationKind = "undeclared_key"
+	ViolationMissingKey    ViolationKind = "missing_key"
+	ViolationNoSchema      ViolationKind = "no_schema"
+)
+
+// Violation is a single way in which records of a stream disagree with the stream's
+// schema, along with how many times we saw it.
+type Violation struct {
+	Stream string        `json:"stream"`
+	Kind   ViolationKind `json:"kind"`
+	// Path is the location of the value in the record, e.g. "severity.rank", where "[]"
+	// marks an array element.
+	Path string `json:"path"`
+	// Detail describes the first occurrence, e.g. what type was expected.
+	Detail string `json:"detail"`
+	// Count is how many times we saw this violation.
+	Count int `json:"count"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s at %s (%d times): %s", v.Stream, v.Kind, v.Path, v.Count, v.Detail)
+}
+
+// RecordValidator checks every record against the most recent schema of its stream,
+// exactly as a target would after reading our SCHEMA and RECORD messages.
+//
+// Records are validated in their JSON form, so a time.Time is a string and an int64 is a
+// number, which is what the target sees.
+type RecordValidator struct {
+	mu         sync.Mutex
+	schemas    map[string]*model.Schema
+	violations map[string]*Violation
+}
+
+func NewRecordValidator() *RecordValidator {
+	return &RecordValidator{
+		schemas:    map[string]*model.Schema{},
+		violations: map[string]*Violation{},
+	}
+}
+
+// Observe records the schema from SCHEMA messages and validates RECORD messages.
+func (v *RecordValidator) Observe(op *Output) error {
+	switch op.Type {
+	case OutputTypeSchema:
+		v.mu.Lock()
+		v.schemas[op.Stream] = op.Schema
+		v.mu.Unlock()
+	case OutputTypeRecord:
+		return v.Validate(op.Stream, op.Record)
+	}
+
+	return nil
+}
+
+// Validate checks a single record against the schema of its stream.
+func (v *RecordValidator) Validate(stream string, record map[string]any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value map[string]any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	schema, ok := v.schemas[stream]
+	if !ok {
+		v.add(stream, ViolationNoSchema, "", "record emitted before the stream's schema")
+		return nil
+	}
+
+	report := func(kind ViolationKind, path, detail string) {
+		v.add(stream, kind, path, detail)
+	}
+	properties := schema.Properties
+	if value[DeletedAtProperty] != nil {
+		// Records marking a deletion only carry the key, so we only check what's there.
+		properties = lo.PickByKeys(properties, lo.Keys(value))
+	}
+	validateObject(report, "", properties, value, !schema.HasAdditionalProperties)
+
+	return nil
+}
+
+// Violations returns every violation seen so far, ordered by stream and path.
+func (v *RecordValidator) Violations() []Violation {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	violations := lo.Map(lo.Values(v.violations), func(violation *Violation, _ int) Violation {
+		return *violation
+	})
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Stream != violations[j].Stream {
+			return violations[i].Stream < violations[j].Stream
+		}
+		if violations[i].Path != violations[j].Path {
+			return violations[i].Path < violations[j].Path
+		}
+		return violations[i].Kind < violations[j].Kind
+	})
+
+	return violations
+}
+
+func (v *RecordValidator) add(stream string, kind ViolationKind, path, detail string) {
+	key := strings.Join([]string{stream, string(kind), path}, "/")
+	if existing, ok := v.violations[key]; ok {
+		existing.Count++
+		return
+	}
+
+	v.violations[key] = &Violation{Stream: stream, Kind: kind, Path: path, Detail: detail, Count: 1}
+}
+
+type reportFunc func(kind ViolationKind, path, detail string)
+
+// validateObject checks an object's values against the declared properties. We treat
+// nested objects with declared properties as closed, as a target creating columns from
+// the schema would drop (or reject) anything else.
+func validateObject(report reportFunc, path string, properties map[string]model.Property, value map[string]any, closed bool) {
+	for name, property := range properties {
+		child, ok := value[name]
+		if !ok {
+			report(ViolationMissingKey, join(path, name), "declared in the schema but not in the record")
+			continue
+		}
+
+		validateValue(report, join(path, name), property.Types, property.CustomFormat, property.Properties, property.Items, child)
+	}
+
+	if !closed {
+		return
+	}
+
+	for name, child := range value {
+		if _, ok := properties[name]; !ok {
+			report(ViolationUndeclaredKey, join(path, name), fmt.Sprintf("not declared in the schema, value is %s", typeOf(child)))
+		}
+	}
+}
+
+func validateValue(report reportFunc, path string, types []string, format string, properties map[string]model.Property, items *model.ArrayItem, value any) {
+	actual := typeOf(value)
+	if !allowsType(types, actual) {
+		report(ViolationTypeMismatch, path, fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), actual))
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		if format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				report(ViolationInvalidFormat, path, fmt.Sprintf("expected a date-time, got %q", value))
+			}
+		}
+	case map[string]any:
+		if len(properties) > 0 {
+			validateObject(report, path, properties, value, true)
+		}
+	case []any:
+		if items == nil {
+			return
+		}
+		for _, element := range value {
+			validateValue(report, path+"[]", []string{items.Type}, "", items.Properties, nil, element)
+		}
+	}
+}
+
+// typeOf returns the JSON schema type of a decoded JSON value.
+func typeOf(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// allowsType returns whether a value of the given JSON type matches the schema types,
+// where an integer is also a number.
+func allowsType(types []string, actual string) bool {
+	for _, t := range types {
+		if strings.EqualFold(t, actual) || (strings.EqualFold(t, "number") && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
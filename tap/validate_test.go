@@ -0,0 +1,171 @@
+package tap_test
+
+import (
+	"context"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/incident-io/singer-tap/client"
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/incident-io/singer-tap/tap"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecordValidator", func() {
+	var (
+		validator *tap.RecordValidator
+	)
+
+	BeforeEach(func() {
+		validator = tap.NewRecordValidator()
+		Expect(validator.Observe(&tap.Output{
+			Type:   tap.OutputTypeSchema,
+			Stream: "things",
+			Schema: &model.Schema{
+				Type: []string{"object"},
+				Properties: map[string]model.Property{
+					"id":         {Types: []string{"string"}},
+					"rank":       {Types: []string{"integer"}},
+					"created_at": model.DateTime.Schema(),
+					"owner": model.Optional(model.Property{
+						Types: []string{"object"},
+						Properties: map[string]model.Property{
+							"name": {Types: []string{"string"}},
+						},
+					}),
+					"tags": model.ArrayOf(model.Property{
+						Types: []string{"object"},
+						Properties: map[string]model.Property{
+							"label": {Types: []string{"string"}},
+						},
+					}),
+				},
+			},
+		})).To(Succeed())
+	})
+
+	validate := func(record map[string]any) []tap.Violation {
+		Expect(validator.Validate("things", record)).To(Succeed())
+		return validator.Violations()
+	}
+
+	It("accepts records matching the schema", func() {
+		Expect(validate(map[string]any{
+			"id":         "01THING",
+			"rank":       int64(3),
+			"created_at": time.Now(),
+			"owner":      nil,
+			"tags":       []map[string]any{{"label": "a"}},
+		})).To(BeEmpty())
+	})
+
+	It("reports mismatched types, including within arrays", func() {
+		violations := validate(map[string]any{
+			"id":         "01THING",
+			"rank":       1.5,
+			"created_at": "yesterday",
+			"owner":      map[string]any{"name": 7},
+			"tags":       []map[string]any{{"label": true}},
+		})
+
+		Expect(violations).To(ConsistOf(
+			MatchViolation(tap.ViolationInvalidFormat, "created_at"),
+			MatchViolation(tap.ViolationTypeMismatch, "owner.name"),
+			MatchViolation(tap.ViolationTypeMismatch, "rank"),
+			MatchViolation(tap.ViolationTypeMismatch, "tags[].label"),
+		))
+	})
+
+	It("reports undeclared and missing keys", func() {
+		violations := validate(map[string]any{
+			"id":         "01THING",
+			"created_at": time.Now(),
+			"owner":      map[string]any{"name": "Lisa", "email": "lisa@example.com"},
+			"tags":       []any{},
+			"extra":      "surprise",
+		})
+
+		Expect(violations).To(ConsistOf(
+			MatchViolation(tap.ViolationUndeclaredKey, "extra"),
+			MatchViolation(tap.ViolationUndeclaredKey, "owner.email"),
+			MatchViolation(tap.ViolationMissingKey, "rank"),
+		))
+	})
+
+	It("counts repeated violations", func() {
+		validate(map[string]any{"id": 1})
+		violations := validate(map[string]any{"id": 2})
+
+		Expect(violations).To(ContainElement(SatisfyAll(
+			MatchViolation(tap.ViolationTypeMismatch, "id"),
+			HaveField("Count", 2),
+		)))
+	})
+
+	It("reports records for streams without a schema", func() {
+		Expect(validator.Validate("unknown", map[string]any{})).To(Succeed())
+		Expect(validator.Violations()).To(ContainElement(MatchViolation(tap.ViolationNoSchema, "")))
+	})
+})
+
+// Every stream the fake API can serve is checked against its own schema, which catches
+// a model's Serialize drifting from its Schema.
+var _ = Describe("Stream records", func() {
+	var (
+		ctx    context.Context
+		logger kitlog.Logger
+		cl     *client.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		logger = kitlog.NewNopLogger()
+
+		data := fakeapi.Generate(1, fakeapi.Size{
+			Incidents:              10,
+			UpdatesPerIncident:     2,
+			AttachmentsPerIncident: 1,
+			FollowUpsPerIncident:   1,
+			Alerts:                 10,
+			Escalations:            10,
+			Users:                  10,
+			CustomFields:           2,
+			OptionsPerCustomField:  2,
+		})
+		cl = newFakeTap(data, nil).Client
+	})
+
+	DescribeTable("match their schema",
+		func(stream tap.Stream) {
+			validator := tap.NewRecordValidator()
+			Expect(validator.Observe(stream.Output())).To(Succeed())
+
+			records, err := stream.GetRecords(ctx, logger, cl, config.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).NotTo(BeEmpty())
+
+			for _, record := range records {
+				Expect(validator.Validate(stream.Output().Stream, record)).To(Succeed())
+			}
+			Expect(validator.Violations()).To(BeEmpty())
+		},
+		Entry("alerts", &tap.StreamAlerts{}),
+		Entry("custom_fields", &tap.StreamCustomFields{}),
+		Entry("custom_field_options", &tap.StreamCustomFieldOptions{}),
+		Entry("escalations", &tap.StreamEscalations{}),
+		Entry("follow_ups", &tap.StreamFollowUps{}),
+		Entry("incidents", &tap.StreamIncidents{}),
+		Entry("incident_metrics", &tap.StreamIncidentMetrics{}),
+		Entry("incident_timestamp_values", &tap.StreamIncidentTimestampValues{}),
+		Entry("incident_updates", &tap.StreamIncidentUpdates{}),
+		Entry("users", &tap.StreamUsers{}),
+	)
+})
+
+func MatchViolation(kind tap.ViolationKind, path string) OmegaMatcher {
+	return SatisfyAll(HaveField("Kind", kind), HaveField("Path", path))
+}
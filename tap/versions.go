@@ -0,0 +1,149 @@
+package tap
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/model"
+	"github.com/samber/lo"
+)
+
+// DeletedAtProperty is added to full-table streams in soft-delete mode, and is set on
+// records that have been deleted since the last sync.
+const DeletedAtProperty = "_sdc_deleted_at"
+
+// State is what we output in STATE messages, and are given back through --state on the
+// next sync.
+type State struct {
+	Bookmarks map[string]Bookmark `json:"bookmarks"`
+}
+
+// Bookmark is what we remember about a stream between syncs.
+type Bookmark struct {
+	// Version is the table version of the last sync of a full-table stream.
+	Version int64 `json:"version,omitempty"`
+	// IDs are the keys of every record in the last sync, if the stream is in soft-delete
+	// mode, so we can tell which have since been deleted.
+	IDs []string `json:"ids,omitempty"`
+}
+
+// NewState returns an empty state, as used for a first sync.
+func NewState() *State {
+	return &State{Bookmarks: map[string]Bookmark{}}
+}
+
+// Clone returns a copy of the state that can be updated without changing the original.
+func (s *State) Clone() *State {
+	clone := NewState()
+	if s != nil {
+		for stream, bookmark := range s.Bookmarks {
+			clone.Bookmarks[stream] = bookmark
+		}
+	}
+
+	return clone
+}
+
+// fullTable is how we sync a stream that returns every record each time, where a record
+// no longer appearing means it has been deleted.
+//
+// By default, we emit a new table version each sync, wrapping the records in
+// ACTIVATE_VERSION messages so the target can drop rows from previous versions. In
+// soft-delete mode, we instead emit a record marked with _sdc_deleted_at for every id
+// that was present in the last sync but is now missing.
+type fullTable struct {
+	stream     string
+	key        string
+	softDelete bool
+	previous   Bookmark
+	version    int64
+}
+
+func newFullTable(op *Output, cfg config.Config, previous Bookmark, now time.Time) *fullTable {
+	if !lo.Contains(config.FullTableStreams, op.Stream) || len(op.KeyProperties) == 0 {
+		return nil
+	}
+
+	return &fullTable{
+		stream:     op.Stream,
+		key:        op.KeyProperties[0],
+		softDelete: cfg.Stream(op.Stream).SoftDelete,
+		previous:   previous,
+		version:    now.UnixMilli(),
+	}
+}
+
+// Versioned returns whether records are output with a table version, rather than in
+// soft-delete mode.
+func (t *fullTable) Versioned() bool {
+	return !t.softDelete
+}
+
+// Schema adds the _sdc_deleted_at property in soft-delete mode.
+func (t *fullTable) Schema(op *Output) *Output {
+	if t.softDelete {
+		op.Schema.Properties = lo.Assign(op.Schema.Properties, map[string]model.Property{
+			DeletedAtProperty: model.Optional(model.DateTime.Schema()),
+		})
+	}
+
+	return op
+}
+
+// Before returns the messages to output before any records. Targets expect to see an
+// ACTIVATE_VERSION before the first version of a table is loaded, so they create it.
+func (t *fullTable) Before() []*Output {
+	if t.softDelete || t.previous.Version != 0 {
+		return nil
+	}
+
+	return []*Output{t.activateVersion()}
+}
+
+// Record prepares a live record for output.
+func (t *fullTable) Record(op *Output) *Output {
+	if t.softDelete {
+		op.Record[DeletedAtProperty] = nil
+	} else {
+		op.Version = lo.ToPtr(t.version)
+	}
+
+	return op
+}
+
+// After returns the messages to output once every record has been output, along with
+// the bookmark to remember for the next sync.
+func (t *fullTable) After(records []map[string]any, timeExtracted string) ([]*Output, Bookmark) {
+	if !t.softDelete {
+		return []*Output{t.activateVersion()}, Bookmark{Version: t.version}
+	}
+
+	ids := lo.Map(records, func(record map[string]any, _ int) string {
+		return fmt.Sprint(record[t.key])
+	})
+	sort.Strings(ids)
+
+	deleted := lo.Without(t.previous.IDs, ids...)
+
+	return lo.Map(deleted, func(id string, _ int) *Output {
+		return &Output{
+			Type:   OutputTypeRecord,
+			Stream: t.stream,
+			Record: map[string]any{
+				t.key:             id,
+				DeletedAtProperty: timeExtracted,
+			},
+			TimeExtracted: timeExtracted,
+		}
+	}), Bookmark{IDs: ids}
+}
+
+func (t *fullTable) activateVersion() *Output {
+	return &Output{
+		Type:    OutputTypeActivateVersion,
+		Stream:  t.stream,
+		Version: lo.ToPtr(t.version),
+	}
+}
@@ -0,0 +1,137 @@
+package tap_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/incident-io/singer-tap/config"
+	"github.com/incident-io/singer-tap/internal/fakeapi"
+	"github.com/incident-io/singer-tap/tap"
+	"github.com/samber/lo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Full-table streams", func() {
+	var (
+		fake *fakeTap
+		data *fakeapi.Data
+		cfg  config.Config
+	)
+
+	BeforeEach(func() {
+		data = fakeapi.Generate(1, fakeapi.Size{CustomFields: 3})
+		cfg = config.Config{}
+
+		fake = newFakeTap(data, map[string]tap.Stream{
+			"custom_fields": &tap.StreamCustomFields{},
+			"users":         &tap.StreamUsers{},
+		})
+	})
+
+	// sync runs a sync, returning what was output and the final state.
+	sync := func(state *tap.State) ([]tap.Output, *tap.State) {
+		outputs := fake.SyncOutputs(state, cfg)
+
+		states := lo.Filter(outputs, func(op tap.Output, _ int) bool { return op.Type == tap.OutputTypeState })
+		Expect(states).NotTo(BeEmpty())
+
+		encoded, err := json.Marshal(states[len(states)-1].Value)
+		Expect(err).NotTo(HaveOccurred())
+		next, err := config.ParseContents(encoded, tap.State{})
+		Expect(err).NotTo(HaveOccurred())
+
+		return outputs, next
+	}
+
+	ofStream := func(outputs []tap.Output, stream string) []tap.Output {
+		return lo.Filter(outputs, func(op tap.Output, _ int) bool { return op.Stream == stream })
+	}
+
+	Context("with table versions", func() {
+		It("wraps records in ACTIVATE_VERSION on the first sync", func() {
+			outputs, state := sync(nil)
+
+			customFields := ofStream(outputs, "custom_fields")
+			Expect(lo.Map(customFields, func(op tap.Output, _ int) tap.OutputType { return op.Type })).To(Equal([]tap.OutputType{
+				tap.OutputTypeSchema,
+				tap.OutputTypeActivateVersion,
+				tap.OutputTypeRecord,
+				tap.OutputTypeRecord,
+				tap.OutputTypeRecord,
+				tap.OutputTypeActivateVersion,
+			}))
+
+			version := state.Bookmarks["custom_fields"].Version
+			Expect(version).NotTo(BeZero())
+			for _, op := range customFields[1:] {
+				Expect(op.Version).To(Equal(lo.ToPtr(version)))
+			}
+		})
+
+		It("only activates the version after records on later syncs", func() {
+			_, state := sync(nil)
+			outputs, _ := sync(state)
+
+			customFields := ofStream(outputs, "custom_fields")
+			Expect(customFields[1].Type).To(Equal(tap.OutputTypeRecord))
+			Expect(customFields[len(customFields)-1].Type).To(Equal(tap.OutputTypeActivateVersion))
+		})
+
+		It("leaves other streams alone", func() {
+			outputs, state := sync(nil)
+
+			Expect(ofStream(outputs, "users")).To(HaveEach(HaveField("Version", BeNil())))
+			Expect(state.Bookmarks).NotTo(HaveKey("users"))
+		})
+
+		It("keeps bookmarks of streams that weren't synced", func() {
+			_, state := sync(&tap.State{Bookmarks: map[string]tap.Bookmark{"severities": {Version: 1}}})
+			Expect(state.Bookmarks).To(HaveKeyWithValue("severities", tap.Bookmark{Version: 1}))
+		})
+	})
+
+	Context("with soft deletes", func() {
+		BeforeEach(func() {
+			cfg.Streams = map[string]config.StreamConfig{"custom_fields": {SoftDelete: true}}
+		})
+
+		It("marks records missing since the last sync as deleted", func() {
+			_, state := sync(nil)
+			Expect(state.Bookmarks["custom_fields"].IDs).To(HaveLen(3))
+
+			deleted := data.CustomFields[0]
+			data.CustomFields = data.CustomFields[1:]
+
+			outputs, state := sync(state)
+
+			customFields := ofStream(outputs, "custom_fields")
+			Expect(customFields[0].Schema.Properties).To(HaveKey(tap.DeletedAtProperty))
+			Expect(customFields).To(HaveEach(HaveField("Type", Not(Equal(tap.OutputTypeActivateVersion)))))
+
+			records := lo.FilterMap(customFields, func(op tap.Output, _ int) (map[string]any, bool) {
+				return op.Record, op.Type == tap.OutputTypeRecord
+			})
+			Expect(records).To(HaveLen(3))
+			Expect(records[:2]).To(HaveEach(HaveKeyWithValue(tap.DeletedAtProperty, BeNil())))
+			Expect(records[2]).To(Equal(map[string]any{
+				"id":                  deleted.Id,
+				tap.DeletedAtProperty: customFields[3].TimeExtracted,
+			}))
+
+			Expect(state.Bookmarks["custom_fields"].IDs).To(HaveLen(2))
+			Expect(state.Bookmarks["custom_fields"].IDs).NotTo(ContainElement(deleted.Id))
+		})
+
+		It("produces deletion records that match the schema", func() {
+			_, state := sync(nil)
+			data.CustomFields = data.CustomFields[1:]
+
+			validator := tap.NewRecordValidator()
+			ol := tap.NewOutputLogger(&bytes.Buffer{}).WithValidator(validator)
+			Expect(fake.Sync(ol, state, cfg)).To(Succeed())
+			Expect(validator.Violations()).To(BeEmpty())
+		})
+	})
+})